@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/migration"
+	"picket-answersheet-service/src/internal/repository/postgres"
+)
+
+type IMigrator interface {
+	Up(ctx context.Context) (int, error)
+	Down(ctx context.Context) (bool, error)
+}
+
+// newMigrator returns nil for the memory backend, it has nothing to migrate.
+func newMigrator(iConfig config.IConfig, backend string) IMigrator {
+	if backend == "" {
+		backend = iConfig.GetStorageBackend()
+	}
+
+	switch backend {
+	case config.STORAGE_POSTGRES:
+		return postgres.NewMigrator(iConfig)
+	case config.STORAGE_MEMORY:
+		return nil
+	}
+	return migration.NewMigrator(iConfig.GetMongo().Database(iConfig.GetMongoDatabase()), migration.All(iConfig))
+}
+
+func migrate(config config.IConfig) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply or revert database migrations",
+	}
+
+	root.AddCommand(&cobra.Command{
+		Use:   "up",
+		Short: "Apply every pending migration",
+		Run: func(cmd *cobra.Command, args []string) {
+			migrator := newMigrator(config, "")
+			if migrator == nil {
+				log.Info().Msg("storage backend has nothing to migrate")
+				return
+			}
+			count, err := migrator.Up(context.Background())
+			if err != nil {
+				log.Fatal().Err(err).Msg("migrate up")
+			}
+			log.Info().Int("count", count).Msg("migrate up")
+		},
+	})
+
+	root.AddCommand(&cobra.Command{
+		Use:   "down",
+		Short: "Revert the last applied migration",
+		Run: func(cmd *cobra.Command, args []string) {
+			migrator := newMigrator(config, "")
+			if migrator == nil {
+				log.Info().Msg("storage backend has nothing to migrate")
+				return
+			}
+			reverted, err := migrator.Down(context.Background())
+			if err != nil {
+				log.Fatal().Err(err).Msg("migrate down")
+			}
+			if !reverted {
+				log.Info().Msg("no migration to revert")
+			}
+		},
+	})
+
+	return root
+}
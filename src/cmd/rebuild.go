@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/usecase"
+	"picket-answersheet-service/src/routes"
+)
+
+func rebuildSessions(config config.IConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rebuild-sessions",
+		Short: "Rebuild the sessions read model from the event log",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+
+			storage, err := routes.NewStorage(config, "")
+			if err != nil {
+				log.Fatal().Err(err).Send()
+			}
+			testUsecase := usecase.NewTestUsecase(storage.Test)
+			sessionUsecase := usecase.NewSessionUsecase(storage.Answersheet, testUsecase)
+
+			count, err := sessionUsecase.Rebuild(ctx)
+			if err != nil {
+				log.Fatal().Err(err).Int("count", count).Msg("rebuild sessions")
+			}
+			log.Info().Int("count", count).Msg("rebuild sessions")
+		},
+	}
+}
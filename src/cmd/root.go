@@ -8,7 +8,7 @@ import (
 type funcCmd = func(config config.IConfig) *cobra.Command
 
 func GetRoot(config config.IConfig) *cobra.Command {
-	cmd := []funcCmd{server}
+	cmd := []funcCmd{server, migrate, rebuildSessions}
 	root := &cobra.Command{}
 
 	for _, item := range cmd {
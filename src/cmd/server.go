@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -14,45 +15,83 @@ import (
 	"os"
 	"os/signal"
 	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/broker"
+	"picket-answersheet-service/src/internal/publisher"
+	"picket-answersheet-service/src/internal/transport"
+	"picket-answersheet-service/src/internal/usecase"
 	"picket-answersheet-service/src/middlewares"
 	"picket-answersheet-service/src/routes"
+	"time"
 )
 
 func server(config config.IConfig) *cobra.Command {
-	return &cobra.Command{
+	var backend string
+	command := &cobra.Command{
 		Use: "server",
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			server := grpc.NewServer(grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-				grpc_recovery.UnaryServerInterceptor([]grpc_recovery.Option{grpc_recovery.WithRecoveryHandlerContext(middlewares.HandleGrpcError)}...),
-				otelgrpc.UnaryServerInterceptor(),
-			)))
+			storage, err := routes.NewStorage(config, backend)
+			if err != nil {
+				log.Fatal().Err(err).Send()
+			}
 
-			reflection.Register(server)
-
-			routes.Grpc(ctx, server, config)
+			if migrator := newMigrator(config, backend); migrator != nil {
+				if _, err := migrator.Up(ctx); err != nil {
+					log.Fatal().Err(err).Msg("migrate up")
+				}
+			}
 
 			siginit := make(chan os.Signal, 1)
 			signal.Notify(siginit, os.Interrupt, os.Kill)
-
 			go func() {
-				lis, err := net.Listen("tcp", fmt.Sprintf(":%s", config.GetGrpcPort()))
-				if err != nil {
-					log.Fatal().Err(err).Send()
-				}
-				log.Info().Str("port", config.GetGrpcPort()).Msg("server is running")
-				err = server.Serve(lis)
-				if err != nil {
-					log.Fatal().Err(err).Send()
-				}
+				<-siginit
+				cancel()
 			}()
 
-			<-siginit
-			server.GracefulStop()
-			log.Info().Msg("shutdown server")
+			lis, err := net.Listen("tcp", fmt.Sprintf(":%s", config.GetGrpcPort()))
+			if err != nil {
+				log.Fatal().Err(err).Send()
+			}
+			log.Info().Str("port", config.GetGrpcPort()).Msg("server is running")
 
+			err = serve(ctx, lis, config, storage, broker.NewKafkaBroker(config), publisher.NewPublisher(ctx, config))
+			if err != nil {
+				log.Fatal().Err(err).Send()
+			}
+			log.Info().Msg("shutdown server")
 		},
 	}
+	command.Flags().StringVar(&backend, "storage", "", "storage backend (mongo, postgres or memory), overrides STORAGE_BACKEND")
+	return command
+}
+
+// serve runs the grpc server and the consumers on lis until ctx is done.
+func serve(ctx context.Context, lis net.Listener, config config.IConfig, storage routes.Storage, broker transport.IBroker, publisher usecase.IPublisher) error {
+	recovery := []grpc_recovery.Option{grpc_recovery.WithRecoveryHandlerContext(middlewares.HandleGrpcError)}
+	authenticate := middlewares.Authenticate(config.GetAuthSecret(), time.Now)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+			grpc_recovery.UnaryServerInterceptor(recovery...),
+			otelgrpc.UnaryServerInterceptor(),
+			grpc_auth.UnaryServerInterceptor(authenticate),
+		)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			grpc_recovery.StreamServerInterceptor(recovery...),
+			otelgrpc.StreamServerInterceptor(),
+			grpc_auth.StreamServerInterceptor(authenticate),
+		)),
+	)
+
+	reflection.Register(server)
+
+	routes.Grpc(ctx, server, config, storage, broker, publisher)
+
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	return server.Serve(lis)
 }
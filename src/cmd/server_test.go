@@ -0,0 +1,906 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/broker"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"picket-answersheet-service/src/internal/transport"
+	"picket-answersheet-service/src/internal/usecase"
+	"picket-answersheet-service/src/middlewares"
+	answersheetpb "picket-answersheet-service/src/pb/answer_sheet"
+	"picket-answersheet-service/src/routes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// harnessConfig answers the settings the server reads; any other getter panics
+// through the nil embedded interface.
+type harnessConfig struct {
+	config.IConfig
+}
+
+func (harnessConfig) GetOutboxBatchSize() int                   { return 100 }
+func (harnessConfig) GetOutboxPollInterval() time.Duration      { return 10 * time.Millisecond }
+func (harnessConfig) GetKafkaEventWorkers() int                 { return 2 }
+func (harnessConfig) GetKafkaStartWorkers() int                 { return 1 }
+func (harnessConfig) GetKafkaAnswerWorkers() int                { return 2 }
+func (harnessConfig) GetKafkaSubmitWorkers() int                { return 1 }
+func (harnessConfig) GetDispatcherWorkers() int                 { return 4 }
+func (harnessConfig) GetDispatcherReorderWindow() time.Duration { return 10 * time.Millisecond }
+func (harnessConfig) GetDispatcherParkTimeout() time.Duration   { return 300 * time.Millisecond }
+func (harnessConfig) GetMaxClockSkew() time.Duration            { return time.Minute }
+func (harnessConfig) GetWatchInterval() time.Duration           { return 100 * time.Millisecond }
+func (harnessConfig) GetSweeperInterval() time.Duration         { return 50 * time.Millisecond }
+func (harnessConfig) GetSweeperBatchSize() int                  { return 10 }
+func (harnessConfig) GetAuthSecret() string                     { return harnessSecret }
+
+const harnessSecret = "harness-secret"
+
+type harness struct {
+	t      *testing.T
+	ctx    context.Context
+	broker interface {
+		transport.IBroker
+		usecase.IPublisher
+	}
+	storage routes.Storage
+	client  answersheetpb.AnswerSheetServiceClient
+}
+
+// newHarness boots the server on a local port with the in-process broker and
+// the memory storage, and stops it when the test ends.
+func newHarness(t *testing.T) *harness {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cfg := harnessConfig{}
+	storage, err := routes.NewStorage(cfg, config.STORAGE_MEMORY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	memoryBroker := broker.NewMemoryBroker()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(ctx, lis, cfg, storage, memoryBroker, memoryBroker)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("serve: %v", err)
+		}
+	})
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &harness{
+		t:       t,
+		ctx:     ctx,
+		broker:  memoryBroker,
+		storage: storage,
+		client:  answersheetpb.NewAnswerSheetServiceClient(conn),
+	}
+}
+
+// as returns a context calling the server with a token for identity.
+func (h *harness) as(identity middlewares.Identity) context.Context {
+	h.t.Helper()
+	token, err := middlewares.SignToken(harnessSecret, identity, time.Now().Add(time.Hour))
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return metadata.AppendToOutgoingContext(h.ctx, "authorization", "bearer "+token)
+}
+
+func (h *harness) publish(topic string, value interface{}) {
+	h.t.Helper()
+	b := new(bytes.Buffer)
+	if err := json.NewEncoder(b).Encode(value); err != nil {
+		h.t.Fatal(err)
+	}
+	h.publishRaw(topic, b.Bytes())
+}
+
+func (h *harness) publishRaw(topic string, value []byte) {
+	h.t.Helper()
+	if err := h.broker.Publish(h.ctx, topic, kafka.Message{Value: value}); err != nil {
+		h.t.Fatal(err)
+	}
+}
+
+// syncTest publishes the test on sync-test and waits until it is stored.
+func (h *harness) syncTest(test entities.Test) {
+	h.t.Helper()
+	h.publish("sync-test", test)
+	h.eventually("test synced", func() bool {
+		_, err := h.storage.Test.FindByTestIdAndVersion(h.ctx, test.TestId, test.Version)
+		return err == nil
+	})
+}
+
+// label is the label the option key of the question is shown with in the
+// latest session of the user at the test, every session shuffles its options.
+func (h *harness) label(userId int, testId int, questionId int, key string) string {
+	h.t.Helper()
+	resumed, err := h.client.ResumeSession(h.ctx, &answersheetpb.ResumeSessionRequest{UserId: int64(userId), TestId: int64(testId)})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	for _, item := range resumed.Options {
+		if item.QuestionId != int64(questionId) {
+			continue
+		}
+		for position, option := range item.Options {
+			if option == key {
+				return entities.OPTIONS[position]
+			}
+		}
+	}
+	h.t.Fatalf("option %s of question %d not shown to user %d", key, questionId, userId)
+	return ""
+}
+
+func (h *harness) eventually(what string, condition func() bool) {
+	h.t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// waitJobs reads topic until every job id has been notified.
+func (h *harness) waitJobs(topic string, jobIds ...int) {
+	h.t.Helper()
+	ctx, cancel := context.WithTimeout(h.ctx, 5*time.Second)
+	defer cancel()
+
+	pending := make(map[int]bool)
+	for _, id := range jobIds {
+		pending[id] = true
+	}
+	r := h.broker.Reader(topic, "harness")
+	for len(pending) > 0 {
+		m, err := r.FetchMessage(ctx)
+		if err != nil {
+			h.t.Fatalf("waiting for %s of jobs %v: %v", topic, pending, err)
+		}
+		var payload struct {
+			JobId int `json:"job_id"`
+		}
+		if err := json.Unmarshal(m.Value, &payload); err != nil {
+			h.t.Fatal(err)
+		}
+		delete(pending, payload.JobId)
+	}
+}
+
+func envelope(t *testing.T, eventType string, jobId int, userId int, testId int, payload interface{}) dto.EventEnvelope {
+	t.Helper()
+	now := time.Now()
+	result := dto.EventEnvelope{
+		Type:       eventType,
+		Version:    dto.EnvelopeVersion,
+		JobId:      jobId,
+		UserId:     userId,
+		TestId:     testId,
+		OccurredAt: &now,
+	}
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result.Payload = b
+	}
+	return result
+}
+
+func scoredTest(testId int) entities.Test {
+	return entities.Test{
+		TestId:   testId,
+		Name:     "harness",
+		TimeToDo: 60,
+		Content: &entities.TestContent{MultipleChoice: &entities.TestMultipleChoice{
+			Answers: []entities.TestMultipleChoiceAnswer{
+				{Id: 1, Answer: "A", Score: 2},
+				{Id: 2, Answer: "B", Score: 3},
+				{Id: 3, Answer: "C", Score: 5},
+			},
+		}},
+	}
+}
+
+func TestServer_EventsFlow(t *testing.T) {
+	h := newHarness(t)
+	h.syncTest(scoredTest(2))
+
+	h.publish("answersheet-events", envelope(t, entities.START, 1, 7, 2, nil))
+	h.waitJobs("job-success", 1)
+
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 2, 7, 2, dto.AnswerPayload{QuestionId: 1, Answer: h.label(7, 2, 1, "A")}))
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 3, 7, 2, dto.AnswerPayload{QuestionId: 2, Answer: h.label(7, 2, 2, "C")}))
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 4, 7, 2, dto.AnswerPayload{QuestionId: 2, Answer: h.label(7, 2, 2, "B"), PreviousAnswer: h.label(7, 2, 2, "C")}))
+	h.waitJobs("job-success", 2, 3, 4)
+
+	doing, err := h.client.CheckUserDoingTest(h.ctx, &answersheetpb.CheckUserDoingTestRequest{UserId: 7, TestId: 2})
+	if err != nil || !doing.Check {
+		t.Fatalf("CheckUserDoingTest() = %v, %v", doing, err)
+	}
+
+	beforeEnd := time.Now()
+	h.publish("answersheet-events", envelope(t, entities.END, 5, 7, 2, nil))
+	h.waitJobs("job-success", 5)
+
+	// an answer made before the END but received after it is not counted
+	late := envelope(t, entities.ANSWER, 6, 7, 2, dto.AnswerPayload{QuestionId: 3, Answer: "C"})
+	late.OccurredAt = &beforeEnd
+	h.publish("answersheet-events", late)
+	h.waitJobs("job-fail", 6)
+
+	// so is one made after it, without waiting for a retake that never starts
+	afterEnd := time.Now().Add(time.Minute)
+	later := envelope(t, entities.ANSWER, 7, 7, 2, dto.AnswerPayload{QuestionId: 3, Answer: "C"})
+	later.OccurredAt = &afterEnd
+	start := time.Now()
+	h.publish("answersheet-events", later)
+	h.waitJobs("job-fail", 7)
+	if elapsed := time.Since(start); elapsed >= (harnessConfig{}).GetDispatcherParkTimeout() {
+		t.Fatalf("late answer failed after %v, it was parked", elapsed)
+	}
+
+	submitted, err := h.client.CheckUserSubmitted(h.ctx, &answersheetpb.CheckUserSubmittedRequest{UserId: 7, TestId: 2})
+	if err != nil || !submitted.Data {
+		t.Fatalf("CheckUserSubmitted() = %v, %v", submitted, err)
+	}
+	score, err := h.client.GetScore(h.ctx, &answersheetpb.GetScoreRequest{UserId: 7, TestId: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.Score != 5 {
+		t.Fatalf("GetScore() = %v, want 5", score.Score)
+	}
+}
+
+func TestServer_LegacyTopicsFlow(t *testing.T) {
+	h := newHarness(t)
+	h.syncTest(scoredTest(3))
+
+	now := time.Now()
+	var start dto.StartTestInput
+	start.JobId = 11
+	start.Payload.UserId = 8
+	start.Payload.TestId = 3
+	start.Payload.CreatedAt = &now
+	h.publish("start-test", start)
+	h.waitJobs("job-success", 11)
+
+	var answer dto.UserAnswerInput
+	answer.JobId = 12
+	answer.Payload.UserId = 8
+	answer.Payload.TestId = 3
+	answer.Payload.QuestionId = 3
+	answer.Payload.Answer = h.label(8, 3, 3, "C")
+	answer.Payload.CreatedAt = &now
+	h.publish("answer-test", answer)
+	h.waitJobs("job-success", 12)
+
+	var submit dto.SubmitTestInput
+	submit.JobId = 13
+	submit.Payload.UserId = 8
+	submit.Payload.TestId = 3
+	submit.Payload.CreatedAt = &now
+	// a field the adapter does not know is logged, not dead lettered
+	b, err := json.Marshal(submit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.publishRaw("submit-test", append(b[:len(b)-1], `,"client":"web"}`...))
+	h.waitJobs("job-success", 13)
+
+	current, err := h.client.GetCurrentTest(h.ctx, &answersheetpb.GetCurrentTestRequest{UserId: 8, TestId: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(current.Data) != 1 || current.Data[0].QuestionId != 3 || current.Data[0].Answer != answer.Payload.Answer {
+		t.Fatalf("GetCurrentTest() = %v", current.Data)
+	}
+	score, err := h.client.GetScore(h.ctx, &answersheetpb.GetScoreRequest{UserId: 8, TestId: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.Score != 5 {
+		t.Fatalf("GetScore() = %v, want 5", score.Score)
+	}
+}
+
+func TestServer_FailedJobs(t *testing.T) {
+	h := newHarness(t)
+	h.syncTest(scoredTest(4))
+
+	// an answer whose START never arrives is parked, then failed
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 21, 9, 4, dto.AnswerPayload{QuestionId: 1, Answer: "A"}))
+	h.waitJobs("job-fail", 21)
+
+	// answers outside the question set of the test are rejected
+	h.publish("answersheet-events", envelope(t, entities.START, 22, 10, 4, nil))
+	h.waitJobs("job-success", 22)
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 23, 10, 4, dto.AnswerPayload{QuestionId: 9, Answer: "A"}))
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 24, 10, 4, dto.AnswerPayload{QuestionId: 1, Answer: "E"}))
+	h.waitJobs("job-fail", 23, 24)
+
+	// an undecodable message goes to the dead letter queue
+	h.publishRaw("answersheet-events", []byte("{not json"))
+	ctx, cancel := context.WithTimeout(h.ctx, 5*time.Second)
+	defer cancel()
+	m, err := h.broker.Reader("dead-letter-queue", "harness").FetchMessage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(m.Value) != "{not json" {
+		t.Fatalf("dead letter value = %q", m.Value)
+	}
+}
+
+func TestServer_DeadLetters(t *testing.T) {
+	h := newHarness(t)
+	h.syncTest(scoredTest(4))
+
+	value := func(v interface{}) []byte {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	newer := envelope(t, entities.START, 31, 12, 4, nil)
+	newer.Version = dto.EnvelopeVersion + 1
+	messages := []struct {
+		topic   string
+		message kafka.Message
+	}{
+		{"answersheet-events", kafka.Message{
+			Value:   value(envelope(t, entities.START, 30, 12, 4, nil)),
+			Headers: []kafka.Header{{Key: "content-type", Value: []byte("application/xml")}},
+		}},
+		{"answersheet-events", kafka.Message{Value: value(newer)}},
+		{"start-test", kafka.Message{Value: []byte(`{"job_id":32,"payload":{"user_id":"twelve","test_id":4,"event":"START"}}`)}},
+	}
+	for _, item := range messages {
+		if err := h.broker.Publish(h.ctx, item.topic, item.message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// every message is parked whatever topic it came from, and the job of the
+	// envelope that could still be read is failed
+	ctx, cancel := context.WithTimeout(h.ctx, 5*time.Second)
+	defer cancel()
+	reader := h.broker.Reader("dead-letter-queue", "harness")
+	topics := make(map[string]int)
+	for range messages {
+		m, err := reader.FetchMessage(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, header := range m.Headers {
+			if header.Key == "dlq-topic" {
+				topics[string(header.Value)]++
+			}
+		}
+	}
+	if topics["answersheet-events"] != 2 || topics["start-test"] != 1 {
+		t.Fatalf("dead letter topics = %v", topics)
+	}
+	h.waitJobs("job-fail", 31)
+	if _, err := h.storage.Answersheet.FindLatestSession(h.ctx, 12, 4); !errors.Is(err, entities.ErrNotFound) {
+		t.Fatalf("FindLatestSession() error = %v, want no session", err)
+	}
+}
+
+func TestServer_WatchSession(t *testing.T) {
+	h := newHarness(t)
+	test := scoredTest(5)
+	end := time.Now().Add(time.Second)
+	test.TimeEnd = &end
+	h.syncTest(test)
+
+	stream, err := h.client.WatchSession(h.ctx, &answersheetpb.WatchSessionRequest{UserId: 11, TestId: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := func(updateType string) *answersheetpb.SessionUpdate {
+		t.Helper()
+		for {
+			update, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("waiting for %s: %v", updateType, err)
+			}
+			if update.Type == updateType {
+				return update
+			}
+		}
+	}
+
+	if snapshot := next(entities.UPDATE_SNAPSHOT); snapshot.State != entities.SESSION_NOT_STARTED {
+		t.Fatalf("snapshot = %v", snapshot)
+	}
+
+	h.publish("answersheet-events", envelope(t, entities.START, 31, 11, 5, nil))
+	started := next(entities.UPDATE_STATE)
+	if started.State != entities.SESSION_IN_PROGRESS || started.Deadline == nil || started.RemainingSeconds > 1 {
+		t.Fatalf("started = %v", started)
+	}
+
+	label := h.label(11, 5, 2, "B")
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 32, 11, 5, dto.AnswerPayload{QuestionId: 2, Answer: label}))
+	if answer := next(entities.UPDATE_ANSWER); answer.Answer.GetQuestionId() != 2 || answer.Answer.GetAnswer() != label {
+		t.Fatalf("answer = %v", answer)
+	}
+
+	// nobody submits, the sweeper does once the test window closes
+	if forced := next(entities.UPDATE_FORCE_SUBMIT); forced.State != entities.SESSION_SUBMITTED {
+		t.Fatalf("forced = %v", forced)
+	}
+	score, err := h.client.GetScore(h.ctx, &answersheetpb.GetScoreRequest{UserId: 11, TestId: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.Score != 3 {
+		t.Fatalf("GetScore() = %v, want 3", score.Score)
+	}
+}
+
+func TestServer_AnswerStream(t *testing.T) {
+	h := newHarness(t)
+	h.syncTest(scoredTest(6))
+
+	anonymous, err := h.client.AnswerStream(h.ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := anonymous.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("stream without a token error = %v", err)
+	}
+
+	stream, err := h.client.AnswerStream(h.as(middlewares.Identity{UserId: 12, Role: middlewares.ROLE_STUDENT}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	send := func(commandId string, event *answersheetpb.AnswerSheetEvent) *answersheetpb.CommandAck {
+		t.Helper()
+		if err := stream.Send(&answersheetpb.AnswerCommand{CommandId: commandId, Event: event}); err != nil {
+			t.Fatal(err)
+		}
+		ack, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ack.CommandId != commandId {
+			t.Fatalf("ack for %s, want %s", ack.CommandId, commandId)
+		}
+		return ack
+	}
+	event := func(eventType string, key string, answer *answersheetpb.Answer) *answersheetpb.AnswerSheetEvent {
+		return &answersheetpb.AnswerSheetEvent{Type: eventType, Version: 1, IdempotencyKey: key, JobId: 99, UserId: 12, TestId: 6, Answer: answer}
+	}
+
+	other := event(entities.START, "stream-other", nil)
+	other.UserId = 13
+	if ack := send("c0", other); ack.ErrorCode != transport.ERROR_PERMISSION_DENIED {
+		t.Fatalf("other user ack = %v", ack)
+	}
+	start := send("c1", event(entities.START, "stream-start", nil))
+	if !start.Ok || start.EventId == "" || start.Session == "" {
+		t.Fatalf("start ack = %v", start)
+	}
+	label := h.label(12, 6, 3, "C")
+	answer := send("c2", event(entities.ANSWER, "stream-answer", &answersheetpb.Answer{QuestionId: 3, Answer: label}))
+	if !answer.Ok || answer.EventId == "" || answer.Session != start.Session {
+		t.Fatalf("answer ack = %v", answer)
+	}
+	if again := send("c3", event(entities.ANSWER, "stream-answer", &answersheetpb.Answer{QuestionId: 3, Answer: label})); again.EventId != answer.EventId {
+		t.Fatalf("duplicate ack = %v, want event %s", again, answer.EventId)
+	}
+	invalid := send("c4", event(entities.ANSWER, "stream-invalid", &answersheetpb.Answer{QuestionId: 1, Answer: "E"}))
+	if invalid.Ok || invalid.ErrorCode != transport.ERROR_INVALID_ARGUMENT || len(invalid.FieldErrors) == 0 {
+		t.Fatalf("invalid ack = %v", invalid)
+	}
+	if ack := send("c5", event("PAUSE_ALL", "stream-unknown", nil)); ack.ErrorCode != transport.ERROR_INVALID_ARGUMENT {
+		t.Fatalf("unknown type ack = %v", ack)
+	}
+	if end := send("c6", event(entities.END, "stream-end", nil)); !end.Ok {
+		t.Fatalf("end ack = %v", end)
+	}
+	if late := send("c7", event(entities.ANSWER, "stream-late", &answersheetpb.Answer{QuestionId: 2, Answer: "B"})); late.ErrorCode != transport.ERROR_ANSWER_AFTER_SUBMIT {
+		t.Fatalf("late ack = %v", late)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+
+	score, err := h.client.GetScore(h.ctx, &answersheetpb.GetScoreRequest{UserId: 12, TestId: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.Score != 5 {
+		t.Fatalf("GetScore() = %v, want 5", score.Score)
+	}
+}
+
+func TestServer_Proctor(t *testing.T) {
+	h := newHarness(t)
+	h.syncTest(scoredTest(7))
+
+	stream, err := h.client.WatchTest(h.ctx, &answersheetpb.WatchTestRequest{TestId: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := func(updateType string, userId int64) *answersheetpb.TestUpdate {
+		t.Helper()
+		for {
+			update, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("waiting for %s of user %d: %v", updateType, userId, err)
+			}
+			if update.Type == updateType && update.Participant.UserId == userId {
+				return update
+			}
+		}
+	}
+
+	h.publish("answersheet-events", envelope(t, entities.START, 41, 13, 7, nil))
+	h.publish("answersheet-events", envelope(t, entities.START, 42, 14, 7, nil))
+	h.waitJobs("job-success", 41, 42)
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 43, 13, 7, dto.AnswerPayload{QuestionId: 1, Answer: "A"}))
+	if update := next(entities.UPDATE_ANSWER, 13); update.Participant.Answered != 1 || update.Answer.GetQuestionId() != 1 {
+		t.Fatalf("answer update = %v", update)
+	}
+
+	active, err := h.client.ListActiveSessions(h.ctx, &answersheetpb.ListActiveSessionsRequest{TestId: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active.Participants) != 2 || active.Participants[0].UserId != 13 || active.Participants[0].Answered != 1 {
+		t.Fatalf("ListActiveSessions() = %v", active.Participants)
+	}
+
+	// actions need a proctor of the test and act under the proctor of the token
+	if _, err := h.client.ExtendTime(h.ctx, &answersheetpb.ProctorActionRequest{UserId: 13, TestId: 7, Minutes: 30}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("ExtendTime() without a token error = %v", err)
+	}
+	other := h.as(middlewares.Identity{UserId: 101, Role: middlewares.ROLE_PROCTOR, Tests: []int{8}})
+	if _, err := h.client.ExtendTime(other, &answersheetpb.ProctorActionRequest{UserId: 13, TestId: 7, Minutes: 30}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("ExtendTime() by a proctor of another test error = %v", err)
+	}
+	proctor := h.as(middlewares.Identity{UserId: 100, Role: middlewares.ROLE_PROCTOR, Tests: []int{7}})
+	if _, err := h.client.ExtendTime(proctor, &answersheetpb.ProctorActionRequest{ProctorId: 101, UserId: 13, TestId: 7, Minutes: 30}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("ExtendTime() for another proctor error = %v", err)
+	}
+
+	extended, err := h.client.ExtendTime(proctor, &answersheetpb.ProctorActionRequest{UserId: 13, TestId: 7, Minutes: 30, Reason: "accommodation"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extended.Participant.RemainingSeconds < 89*60 {
+		t.Fatalf("ExtendTime() = %v", extended)
+	}
+	next(entities.UPDATE_EXTEND, 13)
+
+	if _, err := h.client.ForceSubmit(proctor, &answersheetpb.ProctorActionRequest{UserId: 14, TestId: 7, Reason: "left the room"}); err != nil {
+		t.Fatal(err)
+	}
+	if forced := next(entities.UPDATE_FORCE_SUBMIT, 14); forced.Participant.State != entities.SESSION_SUBMITTED {
+		t.Fatalf("forced = %v", forced)
+	}
+	voided, err := h.client.VoidSession(proctor, &answersheetpb.ProctorActionRequest{UserId: 13, TestId: 7, Reason: "phone"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if voided.Participant.State != entities.SESSION_VOIDED {
+		t.Fatalf("VoidSession() = %v", voided)
+	}
+	if _, err := h.client.ExtendTime(proctor, &answersheetpb.ProctorActionRequest{UserId: 13, TestId: 7, Minutes: 5}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("ExtendTime() of a voided session error = %v", err)
+	}
+
+	// every action is in the event log with the proctor and the reason
+	events, err := h.storage.Answersheet.FindBySession(h.ctx, voided.Participant.Session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := events[len(events)-1]
+	if last.Event != entities.VOID || last.ActorId != 100 || last.Reason != "phone" || last.Id.Hex() != voided.EventId {
+		t.Fatalf("last event = %+v", last)
+	}
+	active, err = h.client.ListActiveSessions(h.ctx, &answersheetpb.ListActiveSessionsRequest{TestId: 7})
+	if err != nil || len(active.Participants) != 0 {
+		t.Fatalf("ListActiveSessions() = %v, %v", active, err)
+	}
+}
+
+func TestServer_Accommodations(t *testing.T) {
+	h := newHarness(t)
+	h.syncTest(scoredTest(8))
+
+	// one accommodation from the admin rpc, one from the topic
+	if _, err := h.client.SetAccommodation(h.ctx, &answersheetpb.SetAccommodationRequest{TestId: 8, UserId: 15, Multiplier: 1.5, UpdatedBy: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.client.SetAccommodation(h.ctx, &answersheetpb.SetAccommodationRequest{TestId: 8, UserId: 15, Multiplier: 0.5}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("SetAccommodation() with multiplier 0.5 error = %v", err)
+	}
+	h.publish("accommodations", dto.AccommodationInput{TestId: 8, UserId: 16, ExtraMinutes: 20, UpdatedBy: 100})
+	h.eventually("accommodation from the topic", func() bool {
+		accommodation, err := h.storage.Accommodation.FindByTestIdAndUserId(h.ctx, 8, 16)
+		return err == nil && accommodation.ExtraMinutes == 20
+	})
+
+	for _, item := range []struct {
+		userId  int
+		jobId   int
+		allowed time.Duration
+	}{{15, 51, 90 * time.Minute}, {16, 52, 80 * time.Minute}, {17, 53, 60 * time.Minute}} {
+		h.publish("answersheet-events", envelope(t, entities.START, item.jobId, item.userId, 8, nil))
+		h.waitJobs("job-success", item.jobId)
+
+		result, err := h.client.GetLatestStartTime(h.ctx, &answersheetpb.GetLatestStartTimeRequest{TestId: 8, UserId: int64(item.userId)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := result.Deadline.AsTime().Sub(result.Data.AsTime()); got != item.allowed {
+			t.Fatalf("user %d has %v, want %v", item.userId, got, item.allowed)
+		}
+		if remaining := time.Duration(result.RemainingSeconds) * time.Second; remaining > item.allowed || remaining < item.allowed-time.Minute {
+			t.Fatalf("user %d has %v remaining", item.userId, remaining)
+		}
+	}
+
+	// a session already running gets the new accommodation too
+	if _, err := h.client.SetAccommodation(h.ctx, &answersheetpb.SetAccommodationRequest{TestId: 8, UserId: 17, ExtraMinutes: 15, UpdatedBy: 100}); err != nil {
+		t.Fatal(err)
+	}
+	result, err := h.client.GetLatestStartTime(h.ctx, &answersheetpb.GetLatestStartTimeRequest{TestId: 8, UserId: 17})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.Deadline.AsTime().Sub(result.Data.AsTime()); got != 75*time.Minute {
+		t.Fatalf("user 17 has %v after the accommodation, want 75m", got)
+	}
+
+	// unknown fields are refused like on the other topics
+	h.publishRaw("accommodations", []byte(`{"test_id":8,"user_id":18,"extra_minutes":20,"extra_hours":1}`))
+	h.publish("accommodations", dto.AccommodationInput{TestId: 8, UserId: 19, ExtraMinutes: 20})
+	h.eventually("accommodation after the refused one", func() bool {
+		_, err := h.storage.Accommodation.FindByTestIdAndUserId(h.ctx, 8, 19)
+		return err == nil
+	})
+	if _, err := h.storage.Accommodation.FindByTestIdAndUserId(h.ctx, 8, 18); !errors.Is(err, entities.ErrNotFound) {
+		t.Fatalf("accommodation with unknown fields stored, error = %v", err)
+	}
+}
+
+func TestServer_PauseResume(t *testing.T) {
+	h := newHarness(t)
+	h.syncTest(scoredTest(9))
+
+	h.publish("answersheet-events", envelope(t, entities.START, 61, 18, 9, nil))
+	h.waitJobs("job-success", 61)
+
+	// a student cannot stop the clock from the topic
+	h.publish("answersheet-events", envelope(t, entities.PAUSE, 62, 18, 9, nil))
+	h.waitJobs("job-fail", 62)
+
+	// a proctor pauses, answers are refused until the resume
+	proctor := h.as(middlewares.Identity{UserId: 100, Role: middlewares.ROLE_PROCTOR, Tests: []int{9}})
+	if _, err := h.client.PauseTest(proctor, &answersheetpb.ProctorActionRequest{UserId: 18, TestId: 9, Reason: "network down"}); err != nil {
+		t.Fatal(err)
+	}
+	doing, err := h.client.CheckUserDoingTest(h.ctx, &answersheetpb.CheckUserDoingTestRequest{UserId: 18, TestId: 9})
+	if err != nil || !doing.Check || doing.State != entities.SESSION_PAUSED {
+		t.Fatalf("CheckUserDoingTest() = %v, %v", doing, err)
+	}
+	paused, err := h.client.GetLatestStartTime(h.ctx, &answersheetpb.GetLatestStartTimeRequest{TestId: 9, UserId: 18})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 63, 18, 9, dto.AnswerPayload{QuestionId: 1, Answer: "A"}))
+	h.waitJobs("job-fail", 63)
+	if _, err := h.client.PauseTest(proctor, &answersheetpb.ProctorActionRequest{UserId: 18, TestId: 9}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("PauseTest() of a paused session error = %v", err)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	resumed, err := h.client.ResumeTest(proctor, &answersheetpb.ProctorActionRequest{UserId: 18, TestId: 9, Reason: "network back"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed.Participant.State != entities.SESSION_IN_PROGRESS || resumed.Participant.Deadline.AsTime().Sub(paused.Deadline.AsTime()) < time.Second {
+		t.Fatalf("ResumeTest() = %v, paused deadline %v", resumed, paused.Deadline.AsTime())
+	}
+	if resumed.Participant.RemainingSeconds < paused.RemainingSeconds-1 {
+		t.Fatalf("remaining went from %d to %d while paused", paused.RemainingSeconds, resumed.Participant.RemainingSeconds)
+	}
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 64, 18, 9, dto.AnswerPayload{QuestionId: 1, Answer: "A"}))
+	h.waitJobs("job-success", 64)
+}
+
+func TestServer_ResumeSession(t *testing.T) {
+	h := newHarness(t)
+	test := scoredTest(10)
+	test.Version = 4
+	h.syncTest(test)
+
+	// a user who never started gets NotFound instead of a crash
+	if _, err := h.client.GetCurrentTest(h.ctx, &answersheetpb.GetCurrentTestRequest{UserId: 19, TestId: 10}); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetCurrentTest() before the start error = %v", err)
+	}
+	if _, err := h.client.ResumeSession(h.ctx, &answersheetpb.ResumeSessionRequest{UserId: 19, TestId: 10}); status.Code(err) != codes.NotFound {
+		t.Fatalf("ResumeSession() before the start error = %v", err)
+	}
+	if result, err := h.client.GetLatestStartTime(h.ctx, &answersheetpb.GetLatestStartTimeRequest{UserId: 19, TestId: 10}); err != nil || result.Data != nil {
+		t.Fatalf("GetLatestStartTime() before the start = %v, %v", result, err)
+	}
+
+	h.publish("answersheet-events", envelope(t, entities.START, 71, 19, 10, nil))
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 72, 19, 10, dto.AnswerPayload{QuestionId: 3, Answer: "C"}))
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 73, 19, 10, dto.AnswerPayload{QuestionId: 1, Answer: "B"}))
+	h.waitJobs("job-success", 71, 72, 73)
+
+	result, err := h.client.ResumeSession(h.ctx, &answersheetpb.ResumeSessionRequest{UserId: 19, TestId: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Session == "" || result.Attempt != 1 || result.TestVersion != 4 || result.State != entities.SESSION_IN_PROGRESS || result.Version != 3 {
+		t.Fatalf("ResumeSession() = %v", result)
+	}
+	if len(result.Answers) != 2 || result.Answers[0].QuestionId != 1 || result.Answers[0].Answer != "B" || result.Answers[0].AnsweredAt == nil {
+		t.Fatalf("answers = %v", result.Answers)
+	}
+	if len(result.QuestionOrder) != 3 || len(result.Options) != 3 {
+		t.Fatalf("question order = %v, options %v", result.QuestionOrder, result.Options)
+	}
+	if result.RemainingSeconds < 59*60 || result.ServerTime.AsTime().Sub(time.Now()).Abs() > time.Minute {
+		t.Fatalf("remaining %d at %v", result.RemainingSeconds, result.ServerTime.AsTime())
+	}
+}
+
+func TestServer_ShuffledTest(t *testing.T) {
+	h := newHarness(t)
+	h.syncTest(scoredTest(11))
+
+	h.publish("answersheet-events", envelope(t, entities.START, 81, 20, 11, nil))
+	h.waitJobs("job-success", 81)
+
+	resumed, err := h.client.ResumeSession(h.ctx, &answersheetpb.ResumeSessionRequest{UserId: 20, TestId: 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resumed.QuestionOrder) != 3 || len(resumed.Options) != 3 {
+		t.Fatalf("ResumeSession() order %v options %v", resumed.QuestionOrder, resumed.Options)
+	}
+	current, err := h.client.GetCurrentTest(h.ctx, &answersheetpb.GetCurrentTestRequest{UserId: 20, TestId: 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(current.QuestionOrder, resumed.QuestionOrder) || len(current.Options) != 3 || !reflect.DeepEqual(current.Options[0].Options, resumed.Options[0].Options) {
+		t.Fatalf("GetCurrentTest() order %v options %v", current.QuestionOrder, current.Options)
+	}
+
+	// answer every question with the label the key's answer is shown with
+	keys := map[int64]string{1: "A", 2: "B", 3: "C"}
+	for index, item := range resumed.Options {
+		label := ""
+		for position, option := range item.Options {
+			if option == keys[item.QuestionId] {
+				label = entities.OPTIONS[position]
+			}
+		}
+		jobId := 82 + index
+		h.publish("answersheet-events", envelope(t, entities.ANSWER, jobId, 20, 11, dto.AnswerPayload{QuestionId: int(item.QuestionId), Answer: label}))
+		h.waitJobs("job-success", jobId)
+	}
+	// a new version of the test changes neither the key nor the shuffle the
+	// session started with
+	edited := scoredTest(11)
+	edited.Version = 1
+	edited.Content.MultipleChoice.Answers[0].Answer = "D"
+	edited.Content.MultipleChoice.Answers[0].Options = 5
+	h.syncTest(edited)
+	h.publish("answersheet-events", envelope(t, entities.END, 85, 20, 11, nil))
+	h.waitJobs("job-success", 85)
+
+	score, err := h.client.GetScore(h.ctx, &answersheetpb.GetScoreRequest{UserId: 20, TestId: 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.Score != 10 {
+		t.Fatalf("GetScore() = %v, want 10", score.Score)
+	}
+}
+
+func TestServer_FlagQuestions(t *testing.T) {
+	h := newHarness(t)
+	h.syncTest(scoredTest(12))
+
+	h.publish("answersheet-events", envelope(t, entities.START, 91, 21, 12, nil))
+	h.waitJobs("job-success", 91)
+	h.publish("answersheet-events", envelope(t, entities.ANSWER, 92, 21, 12, dto.AnswerPayload{QuestionId: 1, Answer: h.label(21, 12, 1, "A")}))
+	h.publish("answersheet-events", envelope(t, entities.FLAG, 93, 21, 12, dto.AnswerPayload{QuestionId: 1}))
+	h.publish("answersheet-events", envelope(t, entities.FLAG, 94, 21, 12, dto.AnswerPayload{QuestionId: 3}))
+	h.waitJobs("job-success", 92, 93, 94)
+	h.publish("answersheet-events", envelope(t, entities.FLAG, 95, 21, 12, dto.AnswerPayload{QuestionId: 9}))
+	h.waitJobs("job-fail", 95)
+
+	// flags also come from the answer stream
+	stream, err := h.client.AnswerStream(h.as(middlewares.Identity{UserId: 21, Role: middlewares.ROLE_STUDENT}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.Send(&answersheetpb.AnswerCommand{CommandId: "f1", Event: &answersheetpb.AnswerSheetEvent{
+		Type: entities.UNFLAG, Version: 1, IdempotencyKey: "unflag-1", UserId: 21, TestId: 12, Answer: &answersheetpb.Answer{QuestionId: 1},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ack, err := stream.Recv(); err != nil || !ack.Ok {
+		t.Fatalf("unflag ack = %v, %v", ack, err)
+	}
+
+	resumed, err := h.client.ResumeSession(h.ctx, &answersheetpb.ResumeSessionRequest{UserId: 21, TestId: 12})
+	if err != nil || !reflect.DeepEqual(resumed.Flagged, []int64{3}) {
+		t.Fatalf("ResumeSession() = %v, %v", resumed, err)
+	}
+	// unanswered questions are listed in the order the session shows them
+	var unanswered []int64
+	for _, id := range resumed.QuestionOrder {
+		if id != 1 {
+			unanswered = append(unanswered, id)
+		}
+	}
+	summary, err := h.client.GetSubmitSummary(h.ctx, &answersheetpb.GetSubmitSummaryRequest{UserId: 21, TestId: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Total != 3 || summary.Answered != 1 || !reflect.DeepEqual(summary.Unanswered, unanswered) || !reflect.DeepEqual(summary.Flagged, []int64{3}) {
+		t.Fatalf("GetSubmitSummary() = %v", summary)
+	}
+	current, err := h.client.GetCurrentTest(h.ctx, &answersheetpb.GetCurrentTestRequest{UserId: 21, TestId: 12})
+	if err != nil || len(current.Data) != 1 || !reflect.DeepEqual(current.Flagged, []int64{3}) {
+		t.Fatalf("GetCurrentTest() = %v, %v", current, err)
+	}
+
+	// flags never count in the score
+	h.publish("answersheet-events", envelope(t, entities.END, 96, 21, 12, nil))
+	h.waitJobs("job-success", 96)
+	score, err := h.client.GetScore(h.ctx, &answersheetpb.GetScoreRequest{UserId: 21, TestId: 12})
+	if err != nil || score.Score != 2 {
+		t.Fatalf("GetScore() = %v, %v", score, err)
+	}
+	if _, err := h.client.GetSubmitSummary(h.ctx, &answersheetpb.GetSubmitSummaryRequest{UserId: 22, TestId: 12}); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetSubmitSummary() before the start error = %v", err)
+	}
+}
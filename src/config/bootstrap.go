@@ -10,6 +10,40 @@ type structure struct {
 	AppGrpcPort string `mapstructure:"APP_GRPC_PORT"`
 	DatabaseUrl string `mapstructure:"DATABASE_URL"`
 	KafkaBroker string `mapstructure:"KAFKA_BROKER"`
+
+	StorageBackend string `mapstructure:"STORAGE_BACKEND"`
+	PostgresUrl    string `mapstructure:"POSTGRES_URL"`
+
+	MongoDatabase           string `mapstructure:"MONGO_DATABASE"`
+	MongoEventsCollection   string `mapstructure:"MONGO_EVENTS_COLLECTION"`
+	MongoTestsCollection    string `mapstructure:"MONGO_TESTS_COLLECTION"`
+	MongoSessionsCollection string `mapstructure:"MONGO_SESSIONS_COLLECTION"`
+	MongoOutboxCollection   string `mapstructure:"MONGO_OUTBOX_COLLECTION"`
+
+	MongoAccommodationsCollection string `mapstructure:"MONGO_ACCOMMODATIONS_COLLECTION"`
+
+	KafkaPublishBatchSize    int `mapstructure:"KAFKA_PUBLISH_BATCH_SIZE"`
+	KafkaPublishBatchTimeout int `mapstructure:"KAFKA_PUBLISH_BATCH_TIMEOUT_MS"`
+	KafkaPublishQueueSize    int `mapstructure:"KAFKA_PUBLISH_QUEUE_SIZE"`
+
+	OutboxBatchSize    int `mapstructure:"OUTBOX_BATCH_SIZE"`
+	OutboxPollInterval int `mapstructure:"OUTBOX_POLL_INTERVAL_MS"`
+
+	KafkaEventWorkers       int `mapstructure:"KAFKA_EVENT_WORKERS"`
+	KafkaStartWorkers       int `mapstructure:"KAFKA_START_WORKERS"`
+	KafkaAnswerWorkers      int `mapstructure:"KAFKA_ANSWER_WORKERS"`
+	KafkaSubmitWorkers      int `mapstructure:"KAFKA_SUBMIT_WORKERS"`
+	DispatcherWorkers       int `mapstructure:"DISPATCHER_WORKERS"`
+	DispatcherReorderWindow int `mapstructure:"DISPATCHER_REORDER_WINDOW_MS"`
+	DispatcherParkTimeout   int `mapstructure:"DISPATCHER_PARK_TIMEOUT_MS"`
+
+	MaxClockSkew int `mapstructure:"MAX_CLOCK_SKEW_MS"`
+
+	WatchInterval    int `mapstructure:"WATCH_INTERVAL_MS"`
+	SweeperInterval  int `mapstructure:"SWEEPER_INTERVAL_MS"`
+	SweeperBatchSize int `mapstructure:"SWEEPER_BATCH_SIZE"`
+
+	AuthSecret string `mapstructure:"AUTH_SECRET"`
 }
 
 func bootstrap() structure {
@@ -23,6 +57,32 @@ func bootstrap() structure {
 	viper.AutomaticEnv()
 	viper.SetConfigName(".env")
 
+	viper.SetDefault("STORAGE_BACKEND", "mongo")
+	viper.SetDefault("POSTGRES_URL", "")
+	viper.SetDefault("MONGO_DATABASE", "")
+	viper.SetDefault("MONGO_EVENTS_COLLECTION", "events")
+	viper.SetDefault("MONGO_TESTS_COLLECTION", "tests")
+	viper.SetDefault("MONGO_SESSIONS_COLLECTION", "sessions")
+	viper.SetDefault("MONGO_OUTBOX_COLLECTION", "outbox")
+	viper.SetDefault("MONGO_ACCOMMODATIONS_COLLECTION", "accommodations")
+	viper.SetDefault("KAFKA_PUBLISH_BATCH_SIZE", 100)
+	viper.SetDefault("KAFKA_PUBLISH_BATCH_TIMEOUT_MS", 10)
+	viper.SetDefault("KAFKA_PUBLISH_QUEUE_SIZE", 1000)
+	viper.SetDefault("OUTBOX_BATCH_SIZE", 100)
+	viper.SetDefault("OUTBOX_POLL_INTERVAL_MS", 500)
+	viper.SetDefault("KAFKA_EVENT_WORKERS", 4)
+	viper.SetDefault("KAFKA_START_WORKERS", 1)
+	viper.SetDefault("KAFKA_ANSWER_WORKERS", 10)
+	viper.SetDefault("KAFKA_SUBMIT_WORKERS", 1)
+	viper.SetDefault("DISPATCHER_WORKERS", 16)
+	viper.SetDefault("DISPATCHER_REORDER_WINDOW_MS", 100)
+	viper.SetDefault("DISPATCHER_PARK_TIMEOUT_MS", 10000)
+	viper.SetDefault("MAX_CLOCK_SKEW_MS", 30000)
+	viper.SetDefault("WATCH_INTERVAL_MS", 5000)
+	viper.SetDefault("SWEEPER_INTERVAL_MS", 5000)
+	viper.SetDefault("SWEEPER_BATCH_SIZE", 100)
+	viper.SetDefault("AUTH_SECRET", "")
+
 	viper.ReadInConfig()
 
 	var result structure
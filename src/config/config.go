@@ -2,31 +2,143 @@ package config
 
 import (
 	"context"
+	"fmt"
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"time"
+)
+
+const (
+	STORAGE_MONGO    = "mongo"
+	STORAGE_POSTGRES = "postgres"
+	STORAGE_MEMORY   = "memory"
 )
 
 type config struct {
-	appGrpcPort string
-	mongo       *mongo.Client
-	kafkaBroker string
+	appGrpcPort    string
+	kafkaBroker    string
+	storageBackend string
+
+	mongo    *mongo.Client
+	postgres *gorm.DB
+
+	mongoDatabase           string
+	mongoEventsCollection   string
+	mongoTestsCollection    string
+	mongoSessionsCollection string
+	mongoOutboxCollection   string
+
+	mongoAccommodationsCollection string
+
+	kafkaPublishBatchSize    int
+	kafkaPublishBatchTimeout time.Duration
+	kafkaPublishQueueSize    int
+
+	outboxBatchSize    int
+	outboxPollInterval time.Duration
+
+	kafkaEventWorkers       int
+	kafkaStartWorkers       int
+	kafkaAnswerWorkers      int
+	kafkaSubmitWorkers      int
+	dispatcherWorkers       int
+	dispatcherReorderWindow time.Duration
+	dispatcherParkTimeout   time.Duration
+
+	maxClockSkew time.Duration
+
+	watchInterval    time.Duration
+	sweeperInterval  time.Duration
+	sweeperBatchSize int
+
+	authSecret string
 }
 
 func GetConfig() (*config, error) {
 	structure := bootstrap()
 
-	mongo, err := mongo.Connect(context.TODO(), options.Client().ApplyURI(structure.DatabaseUrl))
-	if err != nil {
-		log.Error().Err(err).Send()
-		return nil, err
+	result := config{
+		appGrpcPort:    structure.AppGrpcPort,
+		kafkaBroker:    structure.KafkaBroker,
+		storageBackend: structure.StorageBackend,
+
+		mongoEventsCollection:   structure.MongoEventsCollection,
+		mongoTestsCollection:    structure.MongoTestsCollection,
+		mongoSessionsCollection: structure.MongoSessionsCollection,
+		mongoOutboxCollection:   structure.MongoOutboxCollection,
+
+		mongoAccommodationsCollection: structure.MongoAccommodationsCollection,
+
+		kafkaPublishBatchSize:    structure.KafkaPublishBatchSize,
+		kafkaPublishBatchTimeout: time.Duration(structure.KafkaPublishBatchTimeout) * time.Millisecond,
+		kafkaPublishQueueSize:    structure.KafkaPublishQueueSize,
+
+		outboxBatchSize:    structure.OutboxBatchSize,
+		outboxPollInterval: time.Duration(structure.OutboxPollInterval) * time.Millisecond,
+
+		kafkaEventWorkers:       structure.KafkaEventWorkers,
+		kafkaStartWorkers:       structure.KafkaStartWorkers,
+		kafkaAnswerWorkers:      structure.KafkaAnswerWorkers,
+		kafkaSubmitWorkers:      structure.KafkaSubmitWorkers,
+		dispatcherWorkers:       structure.DispatcherWorkers,
+		dispatcherReorderWindow: time.Duration(structure.DispatcherReorderWindow) * time.Millisecond,
+		dispatcherParkTimeout:   time.Duration(structure.DispatcherParkTimeout) * time.Millisecond,
+
+		maxClockSkew: time.Duration(structure.MaxClockSkew) * time.Millisecond,
+
+		watchInterval:    time.Duration(structure.WatchInterval) * time.Millisecond,
+		sweeperInterval:  time.Duration(structure.SweeperInterval) * time.Millisecond,
+		sweeperBatchSize: structure.SweeperBatchSize,
+
+		authSecret: structure.AuthSecret,
 	}
 
-	result := config{
-		appGrpcPort: structure.AppGrpcPort,
-		mongo:       mongo,
-		kafkaBroker: structure.KafkaBroker,
+	switch result.storageBackend {
+	case STORAGE_MONGO:
+		mongo, err := mongo.Connect(context.TODO(), options.Client().ApplyURI(structure.DatabaseUrl))
+		if err != nil {
+			log.Error().Err(err).Send()
+			return nil, err
+		}
+		database, err := mongoDatabase(structure.DatabaseUrl, structure.MongoDatabase)
+		if err != nil {
+			log.Error().Err(err).Send()
+			return nil, err
+		}
+		result.mongo = mongo
+		result.mongoDatabase = database
+	case STORAGE_POSTGRES:
+		db, err := gorm.Open(postgres.Open(structure.PostgresUrl), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if err != nil {
+			log.Error().Err(err).Send()
+			return nil, err
+		}
+		result.postgres = db
+	case STORAGE_MEMORY:
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", result.storageBackend)
 	}
 
 	return &result, nil
 }
+
+// mongoDatabase picks MONGO_DATABASE when set, then the database in the path
+// of DATABASE_URL, then "picket".
+func mongoDatabase(uri string, database string) (string, error) {
+	if database != "" {
+		return database, nil
+	}
+	cs, err := connstring.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if cs.Database != "" {
+		return cs.Database, nil
+	}
+	return "picket", nil
+}
@@ -1,21 +1,152 @@
 package config
 
-import "go.mongodb.org/mongo-driver/mongo"
+//go:generate mockgen -source=declare.go -destination=../internal/mocks/config.go -package=mocks
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"time"
+)
 
 type IConfig interface {
 	GetGrpcPort() string
+	GetStorageBackend() string
 	GetMongo() *mongo.Client
+	GetPostgres() *gorm.DB
+	GetMongoDatabase() string
+	GetEventsCollection() string
+	GetTestsCollection() string
+	GetSessionsCollection() string
+	GetOutboxCollection() string
+	GetAccommodationsCollection() string
 	GetKafkaBroker() string
+	GetKafkaPublishBatchSize() int
+	GetKafkaPublishBatchTimeout() time.Duration
+	GetKafkaPublishQueueSize() int
+	GetOutboxBatchSize() int
+	GetOutboxPollInterval() time.Duration
+	GetKafkaEventWorkers() int
+	GetKafkaStartWorkers() int
+	GetKafkaAnswerWorkers() int
+	GetKafkaSubmitWorkers() int
+	GetDispatcherWorkers() int
+	GetDispatcherReorderWindow() time.Duration
+	GetDispatcherParkTimeout() time.Duration
+	GetMaxClockSkew() time.Duration
+	GetWatchInterval() time.Duration
+	GetSweeperInterval() time.Duration
+	GetSweeperBatchSize() int
+	GetAuthSecret() string
 }
 
 func (c config) GetGrpcPort() string {
 	return c.appGrpcPort
 }
 
+func (c config) GetStorageBackend() string {
+	return c.storageBackend
+}
+
 func (c config) GetMongo() *mongo.Client {
 	return c.mongo
 }
 
+func (c config) GetPostgres() *gorm.DB {
+	return c.postgres
+}
+
+func (c config) GetMongoDatabase() string {
+	return c.mongoDatabase
+}
+
+func (c config) GetEventsCollection() string {
+	return c.mongoEventsCollection
+}
+
+func (c config) GetTestsCollection() string {
+	return c.mongoTestsCollection
+}
+
+func (c config) GetSessionsCollection() string {
+	return c.mongoSessionsCollection
+}
+
+func (c config) GetOutboxCollection() string {
+	return c.mongoOutboxCollection
+}
+
+func (c config) GetAccommodationsCollection() string {
+	return c.mongoAccommodationsCollection
+}
+
 func (c config) GetKafkaBroker() string {
 	return c.kafkaBroker
 }
+
+func (c config) GetKafkaPublishBatchSize() int {
+	return c.kafkaPublishBatchSize
+}
+
+func (c config) GetKafkaPublishBatchTimeout() time.Duration {
+	return c.kafkaPublishBatchTimeout
+}
+
+func (c config) GetKafkaPublishQueueSize() int {
+	return c.kafkaPublishQueueSize
+}
+
+func (c config) GetOutboxBatchSize() int {
+	return c.outboxBatchSize
+}
+
+func (c config) GetOutboxPollInterval() time.Duration {
+	return c.outboxPollInterval
+}
+
+func (c config) GetKafkaEventWorkers() int {
+	return c.kafkaEventWorkers
+}
+
+func (c config) GetKafkaStartWorkers() int {
+	return c.kafkaStartWorkers
+}
+
+func (c config) GetKafkaAnswerWorkers() int {
+	return c.kafkaAnswerWorkers
+}
+
+func (c config) GetKafkaSubmitWorkers() int {
+	return c.kafkaSubmitWorkers
+}
+
+func (c config) GetDispatcherWorkers() int {
+	return c.dispatcherWorkers
+}
+
+func (c config) GetDispatcherReorderWindow() time.Duration {
+	return c.dispatcherReorderWindow
+}
+
+func (c config) GetDispatcherParkTimeout() time.Duration {
+	return c.dispatcherParkTimeout
+}
+
+func (c config) GetMaxClockSkew() time.Duration {
+	return c.maxClockSkew
+}
+
+func (c config) GetWatchInterval() time.Duration {
+	return c.watchInterval
+}
+
+func (c config) GetSweeperInterval() time.Duration {
+	return c.sweeperInterval
+}
+
+func (c config) GetSweeperBatchSize() int {
+	return c.sweeperBatchSize
+}
+
+func (c config) GetAuthSecret() string {
+	return c.authSecret
+}
@@ -0,0 +1,33 @@
+package broker
+
+import (
+	"context"
+	"github.com/segmentio/kafka-go"
+	"picket-answersheet-service/src/config"
+	"strings"
+)
+
+// IReader is the part of kafka.Reader the transports use, so a consumer group
+// can be served by Kafka or by the in-process broker.
+type IReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+type kafkaBroker struct {
+	brokers []string
+}
+
+func NewKafkaBroker(config config.IConfig) *kafkaBroker {
+	return &kafkaBroker{brokers: strings.Split(config.GetKafkaBroker(), ",")}
+}
+
+func (b *kafkaBroker) Reader(topic string, groupId string) IReader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers: b.brokers,
+		Topic:   topic,
+		GroupID: groupId,
+		//Logger:  kafka.LoggerFunc(log.Info().Msgf),
+	})
+}
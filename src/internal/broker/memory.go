@@ -0,0 +1,91 @@
+package broker
+
+import (
+	"context"
+	"github.com/segmentio/kafka-go"
+	"sync"
+	"time"
+)
+
+type memoryTopic struct {
+	messages []kafka.Message
+	offsets  map[string]int
+	signal   chan struct{}
+}
+
+// memoryBroker is an in-process stand-in for Kafka used by tests and demos. A
+// topic is a single partition log, readers of the same group share one offset
+// and a message counts as consumed once it has been fetched.
+type memoryBroker struct {
+	mu     sync.Mutex
+	topics map[string]*memoryTopic
+}
+
+func NewMemoryBroker() *memoryBroker {
+	return &memoryBroker{topics: make(map[string]*memoryTopic)}
+}
+
+func (b *memoryBroker) topic(name string) *memoryTopic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &memoryTopic{offsets: make(map[string]int), signal: make(chan struct{})}
+		b.topics[name] = t
+	}
+	return t
+}
+
+func (b *memoryBroker) Publish(ctx context.Context, topic string, messages ...kafka.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topic(topic)
+	for _, m := range messages {
+		m.Topic = topic
+		m.Offset = int64(len(t.messages))
+		m.Time = time.Now()
+		t.messages = append(t.messages, m)
+	}
+	close(t.signal)
+	t.signal = make(chan struct{})
+	return nil
+}
+
+func (b *memoryBroker) Reader(topic string, groupId string) IReader {
+	return &memoryReader{broker: b, topic: topic, groupId: groupId}
+}
+
+type memoryReader struct {
+	broker  *memoryBroker
+	topic   string
+	groupId string
+}
+
+func (r *memoryReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	for {
+		r.broker.mu.Lock()
+		t := r.broker.topic(r.topic)
+		offset := t.offsets[r.groupId]
+		if offset < len(t.messages) {
+			t.offsets[r.groupId] = offset + 1
+			m := t.messages[offset]
+			r.broker.mu.Unlock()
+			return m, nil
+		}
+		signal := t.signal
+		r.broker.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return kafka.Message{}, ctx.Err()
+		case <-signal:
+		}
+	}
+}
+
+func (r *memoryReader) CommitMessages(ctx context.Context, messages ...kafka.Message) error {
+	return nil
+}
+
+func (r *memoryReader) Close() error {
+	return nil
+}
@@ -0,0 +1,116 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/hamba/avro/v2"
+	"picket-answersheet-service/src/internal/dto"
+	"time"
+)
+
+const avroSchema = `{
+	"type": "record",
+	"name": "AnswerSheetEvent",
+	"namespace": "picket.answersheet",
+	"fields": [
+		{"name": "type", "type": "string"},
+		{"name": "version", "type": "int"},
+		{"name": "idempotency_key", "type": "string"},
+		{"name": "job_id", "type": "long"},
+		{"name": "user_id", "type": "long"},
+		{"name": "test_id", "type": "long"},
+		{"name": "occurred_at", "type": ["null", {"type": "long", "logicalType": "timestamp-millis"}], "default": null},
+		{"name": "answer", "type": ["null", {
+			"type": "record",
+			"name": "Answer",
+			"fields": [
+				{"name": "question_id", "type": "long"},
+				{"name": "answer", "type": "string"},
+				{"name": "previous_answer", "type": "string"}
+			]
+		}], "default": null}
+	]
+}`
+
+type avroAnswer struct {
+	QuestionId     int64  `avro:"question_id"`
+	Answer         string `avro:"answer"`
+	PreviousAnswer string `avro:"previous_answer"`
+}
+
+type avroEvent struct {
+	Type           string      `avro:"type"`
+	Version        int         `avro:"version"`
+	IdempotencyKey string      `avro:"idempotency_key"`
+	JobId          int64       `avro:"job_id"`
+	UserId         int64       `avro:"user_id"`
+	TestId         int64       `avro:"test_id"`
+	OccurredAt     *time.Time  `avro:"occurred_at"`
+	Answer         *avroAnswer `avro:"answer"`
+}
+
+type avroCodec struct {
+	schema avro.Schema
+}
+
+func NewAvro() *avroCodec {
+	return &avroCodec{schema: avro.MustParse(avroSchema)}
+}
+
+func (c *avroCodec) ContentType() string {
+	return "avro/binary"
+}
+
+func (c *avroCodec) Encode(envelope *dto.EventEnvelope) ([]byte, error) {
+	event := avroEvent{
+		Type:           envelope.Type,
+		Version:        envelope.Version,
+		IdempotencyKey: envelope.IdempotencyKey,
+		JobId:          int64(envelope.JobId),
+		UserId:         int64(envelope.UserId),
+		TestId:         int64(envelope.TestId),
+		OccurredAt:     envelope.OccurredAt,
+	}
+	if len(envelope.Payload) > 0 {
+		var payload dto.AnswerPayload
+		if err := json.NewDecoder(bytes.NewBuffer(envelope.Payload)).Decode(&payload); err != nil {
+			return nil, err
+		}
+		event.Answer = &avroAnswer{
+			QuestionId:     int64(payload.QuestionId),
+			Answer:         payload.Answer,
+			PreviousAnswer: payload.PreviousAnswer,
+		}
+	}
+	return avro.Marshal(c.schema, event)
+}
+
+func (c *avroCodec) Decode(data []byte) (*dto.EventEnvelope, error) {
+	var event avroEvent
+	if err := avro.Unmarshal(c.schema, data, &event); err != nil {
+		return nil, err
+	}
+
+	envelope := dto.EventEnvelope{
+		Type:           event.Type,
+		Version:        event.Version,
+		IdempotencyKey: event.IdempotencyKey,
+		JobId:          int(event.JobId),
+		UserId:         int(event.UserId),
+		TestId:         int(event.TestId),
+		OccurredAt:     event.OccurredAt,
+	}
+	if event.Answer != nil {
+		b := new(bytes.Buffer)
+		err := json.NewEncoder(b).Encode(dto.AnswerPayload{
+			QuestionId:     int(event.Answer.QuestionId),
+			Answer:         event.Answer.Answer,
+			PreviousAnswer: event.Answer.PreviousAnswer,
+		})
+		if err != nil {
+			return nil, err
+		}
+		envelope.Payload = b.Bytes()
+	}
+	return &envelope, nil
+}
@@ -0,0 +1,107 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"picket-answersheet-service/src/internal/dto"
+	"strings"
+)
+
+const ContentTypeHeader = "content-type"
+
+var ErrUnsupportedContentType = errors.New("unsupported content type")
+var ErrIncompatibleSchema = errors.New("incompatible schema version")
+
+type Codec interface {
+	ContentType() string
+	Encode(envelope *dto.EventEnvelope) ([]byte, error)
+	Decode(data []byte) (*dto.EventEnvelope, error)
+}
+
+type registry struct {
+	codecs   map[string]Codec
+	fallback Codec
+}
+
+// NewRegistry picks a codec from the content-type header of each message.
+// Messages without the header are decoded with the first codec.
+func NewRegistry(codecs ...Codec) *registry {
+	r := &registry{codecs: make(map[string]Codec)}
+	for _, item := range codecs {
+		r.codecs[item.ContentType()] = item
+	}
+	if len(codecs) > 0 {
+		r.fallback = codecs[0]
+	}
+	return r
+}
+
+func NewDefaultRegistry() *registry {
+	return NewRegistry(NewJSON(), NewProtobuf(), NewAvro())
+}
+
+func (r *registry) Get(contentType string) (Codec, error) {
+	if contentType == "" {
+		if r.fallback == nil {
+			return nil, ErrUnsupportedContentType
+		}
+		return r.fallback, nil
+	}
+	// drop parameters such as "; charset=utf-8"
+	contentType = strings.TrimSpace(strings.Split(contentType, ";")[0])
+	result, ok := r.codecs[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedContentType, contentType)
+	}
+	return result, nil
+}
+
+// Decode decodes the message and rejects envelopes whose schema version this
+// service cannot read.
+func (r *registry) Decode(m kafka.Message) (*dto.EventEnvelope, error) {
+	c, err := r.Get(ContentType(m))
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := c.Decode(m.Value)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckVersion(envelope); err != nil {
+		return envelope, err
+	}
+	return envelope, nil
+}
+
+func (r *registry) Encode(contentType string, envelope *dto.EventEnvelope) (kafka.Message, error) {
+	c, err := r.Get(contentType)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+	value, err := c.Encode(envelope)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+	return kafka.Message{
+		Key:     []byte(envelope.Key()),
+		Value:   value,
+		Headers: []kafka.Header{{Key: ContentTypeHeader, Value: []byte(c.ContentType())}},
+	}, nil
+}
+
+func ContentType(m kafka.Message) string {
+	for _, header := range m.Headers {
+		if strings.EqualFold(header.Key, ContentTypeHeader) {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func CheckVersion(envelope *dto.EventEnvelope) error {
+	if envelope.Version < dto.MinEnvelopeVersion || envelope.Version > dto.EnvelopeVersion {
+		return fmt.Errorf("%w: got %d, supported %d-%d", ErrIncompatibleSchema, envelope.Version, dto.MinEnvelopeVersion, dto.EnvelopeVersion)
+	}
+	return nil
+}
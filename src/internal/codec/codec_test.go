@@ -0,0 +1,196 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/segmentio/kafka-go"
+	"picket-answersheet-service/src/internal/dto"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func answerEnvelope(t *testing.T) *dto.EventEnvelope {
+	t.Helper()
+	occurredAt := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	payload, err := json.Marshal(dto.AnswerPayload{QuestionId: 3, Answer: "B", PreviousAnswer: "A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dto.EventEnvelope{
+		Type:           "ANSWER",
+		Version:        dto.EnvelopeVersion,
+		IdempotencyKey: "key-1",
+		JobId:          11,
+		UserId:         1,
+		TestId:         2,
+		OccurredAt:     &occurredAt,
+		Payload:        payload,
+	}
+}
+
+// samePayload compares answer payloads by value, the codecs re-encode them.
+func samePayload(t *testing.T, got json.RawMessage, want json.RawMessage) bool {
+	t.Helper()
+	if len(got) == 0 || len(want) == 0 {
+		return len(got) == len(want)
+	}
+	var gotPayload, wantPayload dto.AnswerPayload
+	if err := json.Unmarshal(got, &gotPayload); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(want, &wantPayload); err != nil {
+		t.Fatal(err)
+	}
+	return gotPayload == wantPayload
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	codecs := []Codec{NewJSON(), NewProtobuf(), NewAvro()}
+	envelopes := map[string]func(t *testing.T) *dto.EventEnvelope{
+		"answer": answerEnvelope,
+		"start without payload": func(t *testing.T) *dto.EventEnvelope {
+			envelope := answerEnvelope(t)
+			envelope.Type = "START"
+			envelope.Payload = nil
+			return envelope
+		},
+	}
+	for _, c := range codecs {
+		for name, build := range envelopes {
+			t.Run(c.ContentType()+"/"+name, func(t *testing.T) {
+				want := build(t)
+				data, err := c.Encode(want)
+				if err != nil {
+					t.Fatalf("Encode() error = %v", err)
+				}
+				got, err := c.Decode(data)
+				if err != nil {
+					t.Fatalf("Decode() error = %v", err)
+				}
+
+				if !samePayload(t, got.Payload, want.Payload) {
+					t.Errorf("Payload = %s, want %s", got.Payload, want.Payload)
+				}
+				if got.OccurredAt == nil || !got.OccurredAt.Equal(*want.OccurredAt) {
+					t.Errorf("OccurredAt = %v, want %v", got.OccurredAt, want.OccurredAt)
+				}
+				got.Payload, want.Payload = nil, nil
+				got.OccurredAt, want.OccurredAt = nil, nil
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("Decode() = %+v, want %+v", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestRegistry_RoundTrip(t *testing.T) {
+	r := NewDefaultRegistry()
+	for _, contentType := range []string{"application/json", "application/x-protobuf", "avro/binary"} {
+		t.Run(contentType, func(t *testing.T) {
+			want := answerEnvelope(t)
+			m, err := r.Encode(contentType, want)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if got := ContentType(m); got != contentType {
+				t.Errorf("ContentType() = %q, want %q", got, contentType)
+			}
+			if string(m.Key) != want.Key() {
+				t.Errorf("Key = %q, want %q", m.Key, want.Key())
+			}
+			got, err := r.Decode(m)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if got.JobId != want.JobId || got.Type != want.Type || !samePayload(t, got.Payload, want.Payload) {
+				t.Errorf("Decode() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestRegistry_Decode(t *testing.T) {
+	encode := func(t *testing.T, version int) []byte {
+		envelope := answerEnvelope(t)
+		envelope.Version = version
+		data, err := NewJSON().Encode(envelope)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	tests := []struct {
+		name    string
+		message func(t *testing.T) kafka.Message
+		wantErr error
+	}{
+		{
+			name: "no content type uses json",
+			message: func(t *testing.T) kafka.Message {
+				return kafka.Message{Value: encode(t, dto.EnvelopeVersion)}
+			},
+		},
+		{
+			name: "content type with parameters",
+			message: func(t *testing.T) kafka.Message {
+				return kafka.Message{Value: encode(t, dto.EnvelopeVersion), Headers: []kafka.Header{{Key: "Content-Type", Value: []byte("application/json; charset=utf-8")}}}
+			},
+		},
+		{
+			name: "unknown content type",
+			message: func(t *testing.T) kafka.Message {
+				return kafka.Message{Value: encode(t, dto.EnvelopeVersion), Headers: []kafka.Header{{Key: ContentTypeHeader, Value: []byte("application/xml")}}}
+			},
+			wantErr: ErrUnsupportedContentType,
+		},
+		{
+			name: "newer envelope version",
+			message: func(t *testing.T) kafka.Message {
+				return kafka.Message{Value: encode(t, dto.EnvelopeVersion+1)}
+			},
+			wantErr: ErrIncompatibleSchema,
+		},
+		{
+			name: "older envelope version",
+			message: func(t *testing.T) kafka.Message {
+				return kafka.Message{Value: encode(t, dto.MinEnvelopeVersion-1)}
+			},
+			wantErr: ErrIncompatibleSchema,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, err := NewDefaultRegistry().Decode(tt.message(t))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() error = %v, want %v", err, tt.wantErr)
+			}
+			// an incompatible envelope is still returned so its job can be failed
+			if errors.Is(err, ErrIncompatibleSchema) && (envelope == nil || envelope.JobId != 11) {
+				t.Errorf("Decode() envelope = %+v, want the decoded envelope", envelope)
+			}
+		})
+	}
+}
+
+func TestJSON_DecodeStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "known fields", data: `{"type":"ANSWER","version":1,"job_id":1,"payload":{"question_id":1,"answer":"A"}}`},
+		{name: "unknown envelope field", data: `{"type":"ANSWER","version":1,"job_id":1,"user":1}`, wantErr: true},
+		{name: "unknown payload field", data: `{"type":"ANSWER","version":1,"job_id":1,"payload":{"questionId":1,"answer":"A"}}`, wantErr: true},
+		{name: "mistyped value", data: `{"type":"ANSWER","version":1,"job_id":"1"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewJSON().Decode([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
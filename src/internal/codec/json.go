@@ -0,0 +1,51 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"picket-answersheet-service/src/internal/dto"
+)
+
+type jsonCodec struct{}
+
+func NewJSON() *jsonCodec {
+	return &jsonCodec{}
+}
+
+func (c *jsonCodec) ContentType() string {
+	return "application/json"
+}
+
+func (c *jsonCodec) Encode(envelope *dto.EventEnvelope) ([]byte, error) {
+	b := new(bytes.Buffer)
+	if err := json.NewEncoder(b).Encode(envelope); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Decode refuses unknown fields and mistyped values instead of leaving zero
+// values behind, both for the envelope and for the answer payload.
+func (c *jsonCodec) Decode(data []byte) (*dto.EventEnvelope, error) {
+	var envelope dto.EventEnvelope
+	if err := DecodeStrict(data, &envelope); err != nil {
+		return nil, err
+	}
+
+	if len(envelope.Payload) > 0 {
+		var payload dto.AnswerPayload
+		if err := DecodeStrict(envelope.Payload, &payload); err != nil {
+			return nil, err
+		}
+	}
+
+	return &envelope, nil
+}
+
+// DecodeStrict decodes JSON into v the way the JSON codec does, refusing
+// unknown fields and mistyped values.
+func DecodeStrict(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewBuffer(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
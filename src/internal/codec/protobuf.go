@@ -0,0 +1,84 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"picket-answersheet-service/src/internal/dto"
+	answersheetpb "picket-answersheet-service/src/pb/answer_sheet"
+)
+
+type protobufCodec struct{}
+
+func NewProtobuf() *protobufCodec {
+	return &protobufCodec{}
+}
+
+func (c *protobufCodec) ContentType() string {
+	return "application/x-protobuf"
+}
+
+func (c *protobufCodec) Encode(envelope *dto.EventEnvelope) ([]byte, error) {
+	message := answersheetpb.AnswerSheetEvent{
+		Type:           envelope.Type,
+		Version:        int32(envelope.Version),
+		IdempotencyKey: envelope.IdempotencyKey,
+		JobId:          int64(envelope.JobId),
+		UserId:         int64(envelope.UserId),
+		TestId:         int64(envelope.TestId),
+	}
+	if envelope.OccurredAt != nil {
+		message.OccurredAt = timestamppb.New(*envelope.OccurredAt)
+	}
+	if len(envelope.Payload) > 0 {
+		var payload dto.AnswerPayload
+		if err := json.NewDecoder(bytes.NewBuffer(envelope.Payload)).Decode(&payload); err != nil {
+			return nil, err
+		}
+		message.Answer = &answersheetpb.Answer{
+			QuestionId: int64(payload.QuestionId),
+			Answer:     payload.Answer,
+		}
+		message.PreviousAnswer = payload.PreviousAnswer
+	}
+	return proto.Marshal(&message)
+}
+
+func (c *protobufCodec) Decode(data []byte) (*dto.EventEnvelope, error) {
+	var message answersheetpb.AnswerSheetEvent
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return nil, err
+	}
+	return FromProto(&message)
+}
+
+// FromProto turns an AnswerSheetEvent, from Kafka or from AnswerStream, into
+// the envelope the transports process.
+func FromProto(message *answersheetpb.AnswerSheetEvent) (*dto.EventEnvelope, error) {
+	envelope := dto.EventEnvelope{
+		Type:           message.Type,
+		Version:        int(message.Version),
+		IdempotencyKey: message.IdempotencyKey,
+		JobId:          int(message.JobId),
+		UserId:         int(message.UserId),
+		TestId:         int(message.TestId),
+	}
+	if message.OccurredAt != nil {
+		occurredAt := message.OccurredAt.AsTime()
+		envelope.OccurredAt = &occurredAt
+	}
+	if message.Answer != nil {
+		b := new(bytes.Buffer)
+		err := json.NewEncoder(b).Encode(dto.AnswerPayload{
+			QuestionId:     int(message.Answer.QuestionId),
+			Answer:         message.Answer.Answer,
+			PreviousAnswer: message.PreviousAnswer,
+		})
+		if err != nil {
+			return nil, err
+		}
+		envelope.Payload = b.Bytes()
+	}
+	return &envelope, nil
+}
@@ -1,10 +1,14 @@
 package dto
 
-import "time"
+import (
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
 
 type StartTestInput struct {
-	JobId   int `json:"job_id"`
-	Payload struct {
+	JobId          int    `json:"job_id"`
+	IdempotencyKey string `json:"idempotency_key"`
+	Payload        struct {
 		UserId    int        `json:"user_id"`
 		TestId    int        `json:"test_id"`
 		Event     string     `json:"event"`
@@ -14,22 +18,24 @@ type StartTestInput struct {
 }
 
 type UserAnswerInput struct {
-	JobId   int `json:"job_id"`
-	Payload struct {
-		UserId         int        `json:"user_id"`
-		TestId         int        `json:"test_id"`
+	JobId          int    `json:"job_id"`
+	IdempotencyKey string `json:"idempotency_key"`
+	Payload        struct {
+		UserId         int        `json:"user_id" validate:"required"`
+		TestId         int        `json:"test_id" validate:"required"`
 		Event          string     `json:"event"`
-		Answer         string     `json:"answer"`
+		Answer         string     `json:"answer" validate:"required,max=1024"`
 		CreatedAt      *time.Time `json:"created_at"`
 		UpdatedAt      *time.Time `json:"updated_at"`
 		PreviousAnswer string     `json:"previous_answer"`
-		QuestionId     int        `json:"question_id"`
+		QuestionId     int        `json:"question_id" validate:"required"`
 	} `json:"payload"`
 }
 
 type SubmitTestInput struct {
-	JobId   int `json:"job_id"`
-	Payload struct {
+	JobId          int    `json:"job_id"`
+	IdempotencyKey string `json:"idempotency_key"`
+	Payload        struct {
 		UserId    int        `json:"user_id"`
 		TestId    int        `json:"test_id"`
 		Event     string     `json:"event"`
@@ -38,6 +44,95 @@ type SubmitTestInput struct {
 	} `json:"payload"`
 }
 
+// FlagQuestionInput marks a question of the latest session of a user for
+// review or clears the mark, Payload.Event is FLAG or UNFLAG.
+type FlagQuestionInput struct {
+	JobId          int    `json:"job_id"`
+	IdempotencyKey string `json:"idempotency_key"`
+	Payload        struct {
+		UserId     int        `json:"user_id" validate:"required"`
+		TestId     int        `json:"test_id" validate:"required"`
+		Event      string     `json:"event" validate:"oneof=FLAG UNFLAG"`
+		QuestionId int        `json:"question_id" validate:"required"`
+		CreatedAt  *time.Time `json:"created_at"`
+		UpdatedAt  *time.Time `json:"updated_at"`
+	} `json:"payload"`
+}
+
+// ProctorActionInput is an action of a proctor on the latest session of a user
+// at a test. Minutes is only read by ExtendTime.
+type ProctorActionInput struct {
+	ProctorId      int    `json:"proctor_id" validate:"required"`
+	UserId         int    `json:"user_id" validate:"required"`
+	TestId         int    `json:"test_id" validate:"required"`
+	Minutes        int    `json:"minutes" validate:"gte=0,lte=1440"`
+	Reason         string `json:"reason" validate:"max=1024"`
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+// AccommodationInput sets the extra time of a user at a test, it is also the
+// message of the accommodations topic. Multiplier is 0 or from 1 up.
+type AccommodationInput struct {
+	TestId       int     `json:"test_id" validate:"required"`
+	UserId       int     `json:"user_id" validate:"required"`
+	ExtraMinutes int     `json:"extra_minutes" validate:"gte=0,lte=1440"`
+	Multiplier   float64 `json:"multiplier" validate:"omitempty,gte=1,lte=5"`
+	UpdatedBy    int     `json:"updated_by"`
+}
+
+// StartTimeOutput is the start and the deadline of a session, Remaining does
+// not run down while it is paused.
+type StartTimeOutput struct {
+	StartedAt *time.Time
+	Deadline  *time.Time
+	Remaining time.Duration
+}
+
+// SessionStatusOutput tells whether the user is doing the test, a paused
+// session counts, and the state of the latest session as seen now.
+type SessionStatusOutput struct {
+	Doing bool
+	State string
+}
+
+// CurrentTestOutput is the answers of the latest session of a user, sorted by
+// question, and the order its questions and options are shown in; Shuffle is
+// nil when the test had no questions to shuffle.
+type CurrentTestOutput struct {
+	Answers []entities.Event
+	Flagged []int
+	Shuffle *entities.Shuffle
+}
+
+// ResumeSessionOutput is what a client needs to pick up the latest session of
+// a user after a crash or on another device. Answers are sorted by question,
+// QuestionOrder is the order the questions are shown in, Options the order of
+// the options of each shuffled question, and ServerTime lets the client
+// correct its own clock.
+type ResumeSessionOutput struct {
+	Session       *entities.Session
+	State         string
+	Answers       []entities.SessionAnswer
+	Flagged       []int
+	QuestionOrder []int
+	Options       map[int][]string
+	Remaining     time.Duration
+	ServerTime    time.Time
+}
+
+// SubmitSummaryOutput is shown before the user submits: the questions left
+// unanswered and the ones flagged for review, both in the order the questions
+// are shown in.
+type SubmitSummaryOutput struct {
+	Session    *entities.Session
+	State      string
+	Total      int
+	Answered   int
+	Unanswered []int
+	Flagged    []int
+	Remaining  time.Duration
+}
+
 type GetScoreInput struct {
 	UserId  int
 	TestId  int
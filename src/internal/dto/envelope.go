@@ -0,0 +1,159 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+// EnvelopeVersion is the schema version this service writes; envelopes from
+// MinEnvelopeVersion up to it can still be read.
+const (
+	EnvelopeVersion    = 1
+	MinEnvelopeVersion = 1
+)
+
+// EventEnvelope is the message carried by the answersheet-events topic. The
+// kafka key is "<user_id>:<test_id>" so every event of one attempt lands on the
+// same partition.
+type EventEnvelope struct {
+	Type           string          `json:"type"`
+	Version        int             `json:"version"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	JobId          int             `json:"job_id"`
+	UserId         int             `json:"user_id"`
+	TestId         int             `json:"test_id"`
+	OccurredAt     *time.Time      `json:"occurred_at"`
+	Payload        json.RawMessage `json:"payload,omitempty"`
+}
+
+type AnswerPayload struct {
+	QuestionId     int    `json:"question_id"`
+	Answer         string `json:"answer"`
+	PreviousAnswer string `json:"previous_answer"`
+}
+
+func (e EventEnvelope) Key() string {
+	return fmt.Sprintf("%d:%d", e.UserId, e.TestId)
+}
+
+func (e EventEnvelope) StartTestInput() StartTestInput {
+	var input StartTestInput
+	input.JobId = e.JobId
+	input.IdempotencyKey = e.IdempotencyKey
+	input.Payload.UserId = e.UserId
+	input.Payload.TestId = e.TestId
+	input.Payload.Event = entities.START
+	input.Payload.CreatedAt = e.OccurredAt
+	input.Payload.UpdatedAt = e.OccurredAt
+	return input
+}
+
+func (e EventEnvelope) UserAnswerInput() (UserAnswerInput, error) {
+	var payload AnswerPayload
+	if err := json.NewDecoder(bytes.NewBuffer(e.Payload)).Decode(&payload); err != nil {
+		return UserAnswerInput{}, err
+	}
+
+	var input UserAnswerInput
+	input.JobId = e.JobId
+	input.IdempotencyKey = e.IdempotencyKey
+	input.Payload.UserId = e.UserId
+	input.Payload.TestId = e.TestId
+	input.Payload.Event = entities.ANSWER
+	input.Payload.QuestionId = payload.QuestionId
+	input.Payload.Answer = payload.Answer
+	input.Payload.PreviousAnswer = payload.PreviousAnswer
+	input.Payload.CreatedAt = e.OccurredAt
+	input.Payload.UpdatedAt = e.OccurredAt
+	return input, nil
+}
+
+func (e EventEnvelope) SubmitTestInput() SubmitTestInput {
+	var input SubmitTestInput
+	input.JobId = e.JobId
+	input.IdempotencyKey = e.IdempotencyKey
+	input.Payload.UserId = e.UserId
+	input.Payload.TestId = e.TestId
+	input.Payload.Event = entities.END
+	input.Payload.CreatedAt = e.OccurredAt
+	input.Payload.UpdatedAt = e.OccurredAt
+	return input
+}
+
+// FlagQuestionInput reads a FLAG or UNFLAG envelope, its payload is an
+// AnswerPayload of which only the question id is read.
+func (e EventEnvelope) FlagQuestionInput() (FlagQuestionInput, error) {
+	var payload AnswerPayload
+	if err := json.NewDecoder(bytes.NewBuffer(e.Payload)).Decode(&payload); err != nil {
+		return FlagQuestionInput{}, err
+	}
+
+	var input FlagQuestionInput
+	input.JobId = e.JobId
+	input.IdempotencyKey = e.IdempotencyKey
+	input.Payload.UserId = e.UserId
+	input.Payload.TestId = e.TestId
+	input.Payload.Event = e.Type
+	input.Payload.QuestionId = payload.QuestionId
+	input.Payload.CreatedAt = e.OccurredAt
+	input.Payload.UpdatedAt = e.OccurredAt
+	return input, nil
+}
+
+// Legacy producers usually send no idempotency key, the job id is unique per
+// message so it is used instead.
+func legacyIdempotencyKey(key string, jobId int) string {
+	if key != "" {
+		return key
+	}
+	return fmt.Sprintf("job-%d", jobId)
+}
+
+func (i StartTestInput) Envelope() EventEnvelope {
+	return EventEnvelope{
+		Type:           entities.START,
+		Version:        EnvelopeVersion,
+		IdempotencyKey: legacyIdempotencyKey(i.IdempotencyKey, i.JobId),
+		JobId:          i.JobId,
+		UserId:         i.Payload.UserId,
+		TestId:         i.Payload.TestId,
+		OccurredAt:     i.Payload.CreatedAt,
+	}
+}
+
+func (i UserAnswerInput) Envelope() (EventEnvelope, error) {
+	b := new(bytes.Buffer)
+	err := json.NewEncoder(b).Encode(AnswerPayload{
+		QuestionId:     i.Payload.QuestionId,
+		Answer:         i.Payload.Answer,
+		PreviousAnswer: i.Payload.PreviousAnswer,
+	})
+	if err != nil {
+		return EventEnvelope{}, err
+	}
+	return EventEnvelope{
+		Type:           entities.ANSWER,
+		Version:        EnvelopeVersion,
+		IdempotencyKey: legacyIdempotencyKey(i.IdempotencyKey, i.JobId),
+		JobId:          i.JobId,
+		UserId:         i.Payload.UserId,
+		TestId:         i.Payload.TestId,
+		OccurredAt:     i.Payload.CreatedAt,
+		Payload:        b.Bytes(),
+	}, nil
+}
+
+func (i SubmitTestInput) Envelope() EventEnvelope {
+	return EventEnvelope{
+		Type:           entities.END,
+		Version:        EnvelopeVersion,
+		IdempotencyKey: legacyIdempotencyKey(i.IdempotencyKey, i.JobId),
+		JobId:          i.JobId,
+		UserId:         i.Payload.UserId,
+		TestId:         i.Payload.TestId,
+		OccurredAt:     i.Payload.CreatedAt,
+	}
+}
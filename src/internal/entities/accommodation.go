@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// Accommodation is the extra time one user is given at a test. Multiplier
+// scales the time to do of the test and ExtraMinutes is added on top; their
+// zero values leave the time unchanged.
+type Accommodation struct {
+	TestId       int        `json:"test_id" bson:"test_id"`
+	UserId       int        `json:"user_id" bson:"user_id"`
+	ExtraMinutes int        `json:"extra_minutes" bson:"extra_minutes"`
+	Multiplier   float64    `json:"multiplier" bson:"multiplier"`
+	UpdatedBy    int        `json:"updated_by" bson:"updated_by"`
+	UpdatedAt    *time.Time `json:"updated_at" bson:"updated_at,omitempty"`
+}
+
+// Extra is the time added to the deadline of a session at a test that gives
+// timeToDo. A nil accommodation adds nothing.
+func (a *Accommodation) Extra(timeToDo time.Duration) time.Duration {
+	if a == nil {
+		return 0
+	}
+	extra := time.Duration(a.ExtraMinutes) * time.Minute
+	if a.Multiplier > 1 {
+		extra += time.Duration(float64(timeToDo) * (a.Multiplier - 1)).Round(time.Second)
+	}
+	return extra
+}
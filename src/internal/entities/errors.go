@@ -0,0 +1,12 @@
+package entities
+
+import "errors"
+
+// ErrNotFound is returned by every storage backend when a lookup matches no
+// record, so callers don't depend on the driver in use.
+var ErrNotFound = errors.New("record not found")
+
+// ErrVersionConflict is returned when a session changed between the read and
+// the write of an event, or when a concurrent START took the same attempt. The
+// event must be handled again against the fresh session.
+var ErrVersionConflict = errors.New("session version conflict")
@@ -5,6 +5,7 @@ import (
 	"time"
 )
 
+// Event is one stored event, stamped with the server time it was received at.
 type Event struct {
 	Id             primitive.ObjectID `bson:"_id,omitempty"`
 	UserId         int                `bson:"user_id,omitempty"`
@@ -15,13 +16,58 @@ type Event struct {
 	PreviousAnswer string             `bson:"previous_answer,omitempty"`
 	CreatedAt      *time.Time         `bson:"created_at,omitempty"`
 	UpdatedAt      *time.Time         `bson:"updated_at,omitempty"`
+	IdempotencyKey string             `bson:"idempotency_key,omitempty"`
+	Reason         string             `bson:"reason,omitempty"`
+
+	ClientCreatedAt *time.Time    `bson:"client_created_at,omitempty"`
+	ClockSkew       time.Duration `bson:"clock_skew,omitempty"`
+	SkewFlagged     bool          `bson:"skew_flagged,omitempty"`
 
 	QuestionId int `bson:"question_id,omitempty"`
+
+	ActorId   int           `bson:"actor_id,omitempty"`
+	Extension time.Duration `bson:"extension,omitempty"`
+
+	TestVersion int      `bson:"test_version,omitempty"`
+	Shuffle     *Shuffle `bson:"shuffle,omitempty"`
 }
 
+// Stamp sets the server time of the event to now and keeps clientTime apart,
+// flagging the event when the two are more than maxSkew apart.
+func (e *Event) Stamp(now time.Time, clientTime *time.Time, maxSkew time.Duration) {
+	e.CreatedAt = &now
+	e.UpdatedAt = &now
+	e.ClientCreatedAt = clientTime
+	e.ClockSkew = 0
+	e.SkewFlagged = false
+	if clientTime == nil {
+		return
+	}
+	e.ClockSkew = now.Sub(*clientTime)
+	if maxSkew > 0 && (e.ClockSkew > maxSkew || e.ClockSkew < -maxSkew) {
+		e.SkewFlagged = true
+	}
+}
+
+// REASON_DEADLINE marks an END written by the service for a session whose
+// time ran out.
+const REASON_DEADLINE = "deadline"
+
+// REASON_ACCOMMODATION marks an EXTEND written by the service when the
+// accommodation of a user with a running session changes.
+const REASON_ACCOMMODATION = "accommodation"
+
 const (
 	START  = "START"
 	DOING  = "DOING"
 	END    = "END"
 	ANSWER = "ANSWER"
+	PAUSE  = "PAUSE"
+	RESUME = "RESUME"
+	VOID   = "VOID"
+	EXTEND = "EXTEND"
+	// FLAG and UNFLAG mark a question for review, they carry its QuestionId
+	// like an ANSWER and never count in the score
+	FLAG   = "FLAG"
+	UNFLAG = "UNFLAG"
 )
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type Outbox struct {
+	Id          primitive.ObjectID `bson:"_id,omitempty"`
+	Topic       string             `bson:"topic"`
+	Payload     []byte             `bson:"payload"`
+	Status      string             `bson:"status"`
+	Attempts    int                `bson:"attempts"`
+	LastError   string             `bson:"last_error,omitempty"`
+	LockedUntil *time.Time         `bson:"locked_until,omitempty"`
+	CreatedAt   *time.Time         `bson:"created_at,omitempty"`
+	SentAt      *time.Time         `bson:"sent_at,omitempty"`
+}
+
+const (
+	OUTBOX_PENDING = "PENDING"
+	OUTBOX_SENT    = "SENT"
+)
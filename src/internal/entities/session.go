@@ -0,0 +1,262 @@
+package entities
+
+import (
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+	"time"
+)
+
+const (
+	SESSION_NOT_STARTED = "NOT_STARTED"
+	SESSION_IN_PROGRESS = "IN_PROGRESS"
+	SESSION_PAUSED      = "PAUSED"
+	SESSION_SUBMITTED   = "SUBMITTED"
+	SESSION_EXPIRED     = "EXPIRED"
+	SESSION_VOIDED      = "VOIDED"
+)
+
+// transitions lists, for every state, the events it accepts and the state they
+// lead to. EXPIRED is never stored: a session in progress becomes expired once
+// its deadline has passed, see StateAt.
+var transitions = map[string]map[string]string{
+	SESSION_NOT_STARTED: {
+		START: SESSION_IN_PROGRESS,
+	},
+	SESSION_IN_PROGRESS: {
+		DOING:  SESSION_IN_PROGRESS,
+		ANSWER: SESSION_IN_PROGRESS,
+		PAUSE:  SESSION_PAUSED,
+		END:    SESSION_SUBMITTED,
+		VOID:   SESSION_VOIDED,
+		EXTEND: SESSION_IN_PROGRESS,
+		FLAG:   SESSION_IN_PROGRESS,
+		UNFLAG: SESSION_IN_PROGRESS,
+	},
+	SESSION_PAUSED: {
+		RESUME: SESSION_IN_PROGRESS,
+		END:    SESSION_SUBMITTED,
+		VOID:   SESSION_VOIDED,
+		EXTEND: SESSION_PAUSED,
+	},
+	// an EXTEND of an expired session is applied to its stored state, which is
+	// still in progress
+	SESSION_EXPIRED: {
+		END:    SESSION_SUBMITTED,
+		VOID:   SESSION_VOIDED,
+		EXTEND: SESSION_IN_PROGRESS,
+	},
+	SESSION_SUBMITTED: {
+		VOID: SESSION_VOIDED,
+	},
+	SESSION_VOIDED: {},
+}
+
+type TransitionError struct {
+	State string
+	Event string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("event %s is not allowed when session is %s", e.Event, e.State)
+}
+
+// Session is the aggregate of the events of one attempt of a user at a test,
+// kept in the sessions read model. Version counts the events applied to it.
+type Session struct {
+	SessionId    string                `bson:"_id,omitempty"`
+	UserId       int                   `bson:"user_id"`
+	TestId       int                   `bson:"test_id"`
+	Attempt      int                   `bson:"attempt"`
+	State        string                `bson:"state"`
+	Answers      map[int]SessionAnswer `bson:"answers,omitempty"`
+	StartedAt    *time.Time            `bson:"started_at,omitempty"`
+	EndedAt      *time.Time            `bson:"ended_at,omitempty"`
+	Deadline     *time.Time            `bson:"deadline,omitempty"`
+	StartEventId primitive.ObjectID    `bson:"start_event_id,omitempty"`
+	LastEventId  primitive.ObjectID    `bson:"last_event_id,omitempty"`
+	Version      int                   `bson:"version"`
+	UpdatedAt    *time.Time            `bson:"updated_at,omitempty"`
+	Extension    time.Duration         `bson:"extension,omitempty"`
+	SkewFlags    int                   `bson:"skew_flags,omitempty"`
+	PausedAt     *time.Time            `bson:"paused_at,omitempty"`
+	Paused       time.Duration         `bson:"paused,omitempty"`
+	TestVersion  int                   `bson:"test_version,omitempty"`
+	Shuffle      *Shuffle              `bson:"shuffle,omitempty"`
+	Flagged      []int                 `bson:"flagged,omitempty"`
+}
+
+type SessionAnswer struct {
+	QuestionId int                `bson:"question_id"`
+	Answer     string             `bson:"answer"`
+	AnsweredAt *time.Time         `bson:"answered_at,omitempty"`
+	EventId    primitive.ObjectID `bson:"event_id"`
+}
+
+func NewSession(userId int, testId int) *Session {
+	return &Session{
+		UserId: userId,
+		TestId: testId,
+		State:  SESSION_NOT_STARTED,
+	}
+}
+
+// RebuildSession replays the events of one session in the order they were
+// stored. Events the state machine refuses are skipped, they were written
+// before transitions were validated and must not count.
+func RebuildSession(events []Event) *Session {
+	s := &Session{State: SESSION_NOT_STARTED}
+	for index := range events {
+		_ = s.Apply(&events[index])
+	}
+	return s
+}
+
+func (s *Session) next(event string) (string, error) {
+	state, ok := transitions[s.State][event]
+	if !ok {
+		return "", &TransitionError{State: s.State, Event: event}
+	}
+	return state, nil
+}
+
+// Can reports whether event is accepted by the session at time now.
+func (s *Session) Can(event string, now time.Time) error {
+	current := *s
+	current.State = s.StateAt(now)
+	_, err := current.next(event)
+	return err
+}
+
+func (s *Session) Apply(event *Event) error {
+	state, err := s.next(event.Event)
+	if err != nil {
+		return err
+	}
+
+	switch event.Event {
+	case START:
+		s.SessionId = event.Session
+		s.UserId = event.UserId
+		s.TestId = event.TestId
+		s.StartedAt = event.CreatedAt
+		s.StartEventId = event.Id
+		s.Answers = make(map[int]SessionAnswer)
+		s.Extension = event.Extension
+		s.TestVersion = event.TestVersion
+		s.Shuffle = event.Shuffle
+	case ANSWER:
+		if s.Answers == nil {
+			s.Answers = make(map[int]SessionAnswer)
+		}
+		s.Answers[event.QuestionId] = SessionAnswer{
+			QuestionId: event.QuestionId,
+			Answer:     event.Answer,
+			AnsweredAt: event.CreatedAt,
+			EventId:    event.Id,
+		}
+	case END:
+		s.EndedAt = event.CreatedAt
+	case EXTEND:
+		s.Extension += event.Extension
+		s.pushDeadline(event.Extension)
+	case FLAG:
+		index := sort.SearchInts(s.Flagged, event.QuestionId)
+		if index == len(s.Flagged) || s.Flagged[index] != event.QuestionId {
+			flagged := make([]int, 0, len(s.Flagged)+1)
+			flagged = append(append(flagged, s.Flagged[:index]...), event.QuestionId)
+			s.Flagged = append(flagged, s.Flagged[index:]...)
+		}
+	case UNFLAG:
+		index := sort.SearchInts(s.Flagged, event.QuestionId)
+		if index < len(s.Flagged) && s.Flagged[index] == event.QuestionId {
+			s.Flagged = append(s.Flagged[:index:index], s.Flagged[index+1:]...)
+		}
+	case PAUSE:
+		s.PausedAt = event.CreatedAt
+	case RESUME:
+		if s.PausedAt != nil && event.CreatedAt != nil && event.CreatedAt.After(*s.PausedAt) {
+			paused := event.CreatedAt.Sub(*s.PausedAt)
+			s.Paused += paused
+			s.pushDeadline(paused)
+		}
+		s.PausedAt = nil
+	}
+	if event.SkewFlagged {
+		s.SkewFlags++
+	}
+	s.State = state
+	s.LastEventId = event.Id
+	s.Version++
+	now := time.Now()
+	s.UpdatedAt = &now
+	return nil
+}
+
+// SortedAnswers lists the answers of the session by question.
+func (s *Session) SortedAnswers() []SessionAnswer {
+	result := make([]SessionAnswer, 0, len(s.Answers))
+	for _, item := range s.Answers {
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].QuestionId < result[j].QuestionId
+	})
+	return result
+}
+
+func (s *Session) pushDeadline(d time.Duration) {
+	if s.Deadline != nil {
+		deadline := s.Deadline.Add(d)
+		s.Deadline = &deadline
+	}
+}
+
+// SetDeadline derives the deadline from the start time, the time allowed by the
+// test and the end of the test window, whichever comes first, pushed back by
+// the accommodation, the extensions granted and the time spent paused so far.
+func (s *Session) SetDeadline(test *Test) {
+	if test == nil || s.StartedAt == nil {
+		return
+	}
+	var deadline *time.Time
+	if test.TimeToDo > 0 {
+		value := s.StartedAt.Add(test.TimeAllowed())
+		deadline = &value
+	}
+	if test.TimeEnd != nil && (deadline == nil || test.TimeEnd.Before(*deadline)) {
+		value := *test.TimeEnd
+		deadline = &value
+	}
+	if deadline != nil {
+		value := deadline.Add(s.Extension + s.Paused)
+		deadline = &value
+	}
+	s.Deadline = deadline
+}
+
+// StateAt is the state as seen at time now, a session in progress whose
+// deadline has passed is reported as expired. A paused session does not
+// expire, its clock is stopped.
+func (s *Session) StateAt(now time.Time) string {
+	if s.Deadline != nil && now.After(*s.Deadline) && s.State == SESSION_IN_PROGRESS {
+		return SESSION_EXPIRED
+	}
+	return s.State
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	state := s.StateAt(now)
+	return state == SESSION_IN_PROGRESS || state == SESSION_PAUSED
+}
+
+const FLAG_CLOCK_SKEW = "CLOCK_SKEW"
+
+// AntiCheatFlags lists what a proctor should look at for this session.
+func (s *Session) AntiCheatFlags() []string {
+	var flags []string
+	if s.SkewFlags > 0 {
+		flags = append(flags, FLAG_CLOCK_SKEW)
+	}
+	return flags
+}
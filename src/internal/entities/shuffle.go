@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Shuffle is the order a session shows the questions of its test in and, for
+// choice questions, the order of their options: Options[questionId][i] is the
+// option of the answer key shown with the i-th label of the question. Answers
+// are stored with the labels the user saw.
+type Shuffle struct {
+	Questions []int            `bson:"questions,omitempty" json:"questions,omitempty"`
+	Options   map[int][]string `bson:"options,omitempty" json:"options,omitempty"`
+}
+
+// NewShuffle derives the order of the questions and options of test from the
+// session id, the same session always gets the same order. Tests without
+// content are not shuffled and get nil.
+func NewShuffle(sessionId string, test *Test) *Shuffle {
+	if test == nil || test.Content == nil || test.Content.MultipleChoice == nil {
+		return nil
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(sessionId))
+	random := rand.New(rand.NewSource(int64(hash.Sum64())))
+
+	result := &Shuffle{Questions: test.QuestionIds(), Options: make(map[int][]string)}
+	random.Shuffle(len(result.Questions), func(i, j int) {
+		result.Questions[i], result.Questions[j] = result.Questions[j], result.Questions[i]
+	})
+	for _, item := range test.Content.MultipleChoice.Answers {
+		if item.Type != QUESTION_CHOICE && item.Type != QUESTION_MULTIPLE {
+			continue
+		}
+		options := append([]string{}, item.Labels()...)
+		random.Shuffle(len(options), func(i, j int) {
+			options[i], options[j] = options[j], options[i]
+		})
+		result.Options[item.Id] = options
+	}
+	return result
+}
+
+// QuestionOrder is the order the questions are shown in, nil when they are
+// not shuffled.
+func (s *Shuffle) QuestionOrder() []int {
+	if s == nil {
+		return nil
+	}
+	return s.Questions
+}
+
+// Canonical maps an answer given with the labels shown to the user back to the
+// labels of the answer key. The options of a multiple answer are listed in
+// label order, as in the key. Other answers are returned unchanged.
+func (s *Shuffle) Canonical(questionId int, answer string) string {
+	if s == nil {
+		return answer
+	}
+	options, ok := s.Options[questionId]
+	if !ok {
+		return answer
+	}
+	labels := OptionLabels(len(options))
+	items := strings.Split(answer, ",")
+	for index, item := range items {
+		item = strings.TrimSpace(item)
+		for position, label := range labels {
+			if item == label {
+				item = options[position]
+				break
+			}
+		}
+		items[index] = item
+	}
+	if len(items) > 1 {
+		sort.Strings(items)
+	}
+	return strings.Join(items, ",")
+}
@@ -5,6 +5,22 @@ import (
 	"time"
 )
 
+// Question types of a TestMultipleChoiceAnswer. CHOICE is the zero value so
+// answer keys synced without a type keep meaning a single option.
+const (
+	QUESTION_CHOICE   = 0
+	QUESTION_MULTIPLE = 1
+	QUESTION_TEXT     = 2
+	QUESTION_NUMBER   = 3
+)
+
+// OPTIONS are the options printed on a multiple choice answer sheet for a
+// question whose answer key does not say how many options it has.
+var OPTIONS = []string{"A", "B", "C", "D"}
+
+// MAX_OPTIONS bounds the options of a question, labelled A to Z.
+const MAX_OPTIONS = 26
+
 type Test struct {
 	Id                 primitive.ObjectID `bson:"_id,omitempty"`
 	TestId             int                `json:"id" gorm:"column:id" bson:"test_id"`
@@ -26,6 +42,27 @@ type Test struct {
 	Content            *TestContent       `json:"content" gorm:"-"`
 }
 
+// TimeAllowed is the time to do of the test, zero for a nil test or one
+// without a time limit.
+func (t *Test) TimeAllowed() time.Duration {
+	if t == nil {
+		return 0
+	}
+	return time.Duration(t.TimeToDo) * time.Minute
+}
+
+// QuestionIds lists the questions of the test in the order of its answer key.
+func (t *Test) QuestionIds() []int {
+	if t == nil || t.Content == nil || t.Content.MultipleChoice == nil {
+		return nil
+	}
+	result := make([]int, len(t.Content.MultipleChoice.Answers))
+	for index, item := range t.Content.MultipleChoice.Answers {
+		result[index] = item.Id
+	}
+	return result
+}
+
 type TestContent struct {
 	Id             int                 `json:"id" gorm:"column:id"`
 	TestId         int                 `json:"test_id" gorm:"column:test_id"`
@@ -42,10 +79,32 @@ type TestMultipleChoiceAnswer struct {
 	Answer               string     `json:"answer" gorm:"column:answer"`
 	Score                float64    `json:"score" gorm:"column:score"`
 	Type                 int        `json:"type" gorm:"column:type"`
+	Options              int        `json:"options" gorm:"column:options"`
 	CreatedAt            *time.Time `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt            *time.Time `json:"updated_at" gorm:"column:updated_at"`
 }
 
+// Labels lists the options of a choice question, OPTIONS when the answer key
+// does not set how many it has.
+func (a TestMultipleChoiceAnswer) Labels() []string {
+	if a.Options <= 0 {
+		return OPTIONS
+	}
+	return OptionLabels(a.Options)
+}
+
+// OptionLabels are the labels of the first n options, A, B and so on.
+func OptionLabels(n int) []string {
+	if n > MAX_OPTIONS {
+		n = MAX_OPTIONS
+	}
+	result := make([]string, n)
+	for index := range result {
+		result[index] = string(rune('A' + index))
+	}
+	return result
+}
+
 type TestMultipleChoice struct {
 	Id        int                        `json:"id" gorm:"column:id"`
 	FilePath  string                     `json:"file_path" gorm:"column:file_path"`
@@ -55,6 +114,19 @@ type TestMultipleChoice struct {
 	Answers   []TestMultipleChoiceAnswer `json:"answers,omitempty"`
 }
 
+// Question returns the answer key of the question, if the test has one.
+func (t *Test) Question(questionId int) (*TestMultipleChoiceAnswer, bool) {
+	if t == nil || t.Content == nil || t.Content.MultipleChoice == nil {
+		return nil, false
+	}
+	for index := range t.Content.MultipleChoice.Answers {
+		if t.Content.MultipleChoice.Answers[index].Id == questionId {
+			return &t.Content.MultipleChoice.Answers[index], true
+		}
+	}
+	return nil, false
+}
+
 func (TestContent) TableName() string {
 	return "test_content"
 }
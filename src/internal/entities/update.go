@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+const (
+	UPDATE_SNAPSHOT     = "SNAPSHOT"
+	UPDATE_STATE        = "STATE"
+	UPDATE_ANSWER       = "ANSWER"
+	UPDATE_TICK         = "TICK"
+	UPDATE_FORCE_SUBMIT = "FORCE_SUBMIT"
+	UPDATE_EXTEND       = "EXTEND"
+	UPDATE_FLAG         = "FLAG"
+)
+
+// SessionUpdate is what watchers of a session are told. Session is a copy of
+// the session after the change, Answer is set for UPDATE_ANSWER.
+type SessionUpdate struct {
+	Type    string
+	Session Session
+	Answer  *SessionAnswer
+	At      time.Time
+}
+
+// Remaining is the time left before the deadline at now, zero once it has
+// passed; it does not run down while the session is paused. ok is false when
+// the session has no deadline.
+func (s *Session) Remaining(now time.Time) (remaining time.Duration, ok bool) {
+	if s.Deadline == nil {
+		return 0, false
+	}
+	if s.PausedAt != nil && s.PausedAt.Before(now) {
+		now = *s.PausedAt
+	}
+	remaining = s.Deadline.Sub(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
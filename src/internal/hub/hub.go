@@ -0,0 +1,66 @@
+package hub
+
+import (
+	"github.com/rs/zerolog/log"
+	"picket-answersheet-service/src/internal/entities"
+	"sync"
+)
+
+const BUFFER_SIZE = 16
+
+type key struct {
+	userId int
+	testId int
+}
+
+// hub fans session updates out to the watchers of the same user and test in
+// this process; watchers subscribed with user id 0 get the updates of every
+// user of the test. A watcher that does not keep up loses updates rather than
+// blocking the ingest path; the periodic resync of WatchSession catches up.
+type hub struct {
+	mu       sync.Mutex
+	watchers map[key]map[chan entities.SessionUpdate]struct{}
+}
+
+func NewHub() *hub {
+	return &hub{watchers: make(map[key]map[chan entities.SessionUpdate]struct{})}
+}
+
+func (h *hub) Subscribe(userId int, testId int) (<-chan entities.SessionUpdate, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	k := key{userId, testId}
+	ch := make(chan entities.SessionUpdate, BUFFER_SIZE)
+	if h.watchers[k] == nil {
+		h.watchers[k] = make(map[chan entities.SessionUpdate]struct{})
+	}
+	h.watchers[k][ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.watchers[k], ch)
+			if len(h.watchers[k]) == 0 {
+				delete(h.watchers, k)
+			}
+		})
+	}
+}
+
+func (h *hub) Publish(update entities.SessionUpdate) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, k := range []key{{update.Session.UserId, update.Session.TestId}, {0, update.Session.TestId}} {
+		for ch := range h.watchers[k] {
+			select {
+			case ch <- update:
+			default:
+				log.Warn().Int("user_id", update.Session.UserId).Int("test_id", update.Session.TestId).Str("type", update.Type).Msg("watcher is behind, update dropped")
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package hub
+
+import (
+	"picket-answersheet-service/src/internal/entities"
+	"testing"
+)
+
+func update(userId int, testId int, updateType string) entities.SessionUpdate {
+	return entities.SessionUpdate{Type: updateType, Session: entities.Session{UserId: userId, TestId: testId}}
+}
+
+func TestHub(t *testing.T) {
+	h := NewHub()
+	first, cancelFirst := h.Subscribe(1, 2)
+	second, cancelSecond := h.Subscribe(1, 2)
+	other, cancelOther := h.Subscribe(1, 3)
+	defer cancelSecond()
+	defer cancelOther()
+
+	h.Publish(update(1, 2, entities.UPDATE_ANSWER))
+	for _, ch := range []<-chan entities.SessionUpdate{first, second} {
+		if got := <-ch; got.Type != entities.UPDATE_ANSWER {
+			t.Fatalf("got %+v", got)
+		}
+	}
+	select {
+	case got := <-other:
+		t.Fatalf("watcher of another test got %+v", got)
+	default:
+	}
+
+	cancelFirst()
+	cancelFirst()
+	h.Publish(update(1, 2, entities.UPDATE_STATE))
+	select {
+	case got := <-first:
+		t.Fatalf("cancelled watcher got %+v", got)
+	default:
+	}
+	if got := <-second; got.Type != entities.UPDATE_STATE {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestHub_WatchWholeTest(t *testing.T) {
+	h := NewHub()
+	test, cancel := h.Subscribe(0, 2)
+	defer cancel()
+
+	h.Publish(update(1, 2, entities.UPDATE_ANSWER))
+	h.Publish(update(5, 2, entities.UPDATE_STATE))
+	h.Publish(update(1, 3, entities.UPDATE_STATE))
+	for _, userId := range []int{1, 5} {
+		if got := <-test; got.Session.UserId != userId {
+			t.Fatalf("got %+v, want user %d", got, userId)
+		}
+	}
+	select {
+	case got := <-test:
+		t.Fatalf("watcher of test 2 got %+v", got)
+	default:
+	}
+}
+
+func TestHub_SlowWatcherDoesNotBlock(t *testing.T) {
+	h := NewHub()
+	ch, cancel := h.Subscribe(1, 2)
+	defer cancel()
+
+	for i := 0; i < BUFFER_SIZE+5; i++ {
+		h.Publish(update(1, 2, entities.UPDATE_ANSWER))
+	}
+	if len(ch) != BUFFER_SIZE {
+		t.Fatalf("buffered %d updates, want %d", len(ch), BUFFER_SIZE)
+	}
+}
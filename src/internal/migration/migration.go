@@ -0,0 +1,174 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sort"
+	"time"
+)
+
+const collectionName = "schema_migrations"
+
+// Migration is one versioned change of the database. Up and Down must be safe
+// to run again, a crash between the change and its record in
+// schema_migrations replays it on the next run.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+	Down    func(ctx context.Context, db *mongo.Database) error
+}
+
+type record struct {
+	Version   int        `bson:"_id"`
+	Name      string     `bson:"name"`
+	AppliedAt *time.Time `bson:"applied_at"`
+}
+
+type migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+}
+
+func NewMigrator(db *mongo.Database, migrations []Migration) *migrator {
+	list := append([]Migration(nil), migrations...)
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+	return &migrator{db: db, migrations: list}
+}
+
+func (m *migrator) applied(ctx context.Context) (map[int]bool, error) {
+	cursor, err := m.db.Collection(collectionName).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	result := make(map[int]bool, len(records))
+	for _, item := range records {
+		result[item.Version] = true
+	}
+	return result, nil
+}
+
+// Up applies every migration that is not recorded yet, in version order, and
+// returns how many were applied.
+func (m *migrator) Up(ctx context.Context) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, item := range m.migrations {
+		if applied[item.Version] {
+			continue
+		}
+		if err := item.Up(ctx, m.db); err != nil {
+			return count, err
+		}
+		now := time.Now()
+		_, err := m.db.Collection(collectionName).InsertOne(ctx, record{Version: item.Version, Name: item.Name, AppliedAt: &now})
+		// another instance applied the same migration concurrently
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return count, err
+		}
+		log.Info().Int("version", item.Version).Str("name", item.Name).Msg("migrate up")
+		count++
+	}
+	return count, nil
+}
+
+// Down reverts the last applied migration. It returns false when there is
+// nothing left to revert.
+func (m *migrator) Down(ctx context.Context) (bool, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for index := len(m.migrations) - 1; index >= 0; index-- {
+		item := m.migrations[index]
+		if !applied[item.Version] {
+			continue
+		}
+		if item.Down != nil {
+			if err := item.Down(ctx, m.db); err != nil {
+				return false, err
+			}
+		}
+		if _, err := m.db.Collection(collectionName).DeleteOne(ctx, bson.M{"_id": item.Version}); err != nil {
+			return false, err
+		}
+		log.Info().Int("version", item.Version).Str("name", item.Name).Msg("migrate down")
+		return true, nil
+	}
+	return false, nil
+}
+
+func createIndex(collection string, model mongo.IndexModel) func(ctx context.Context, db *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		_, err := db.Collection(collection).Indexes().CreateOne(ctx, model)
+		return err
+	}
+}
+
+func dropIndex(collection string, name string) func(ctx context.Context, db *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		_, err := db.Collection(collection).Indexes().DropOne(ctx, name)
+		var commandErr mongo.CommandError
+		// IndexNotFound or NamespaceNotFound, the index is already gone
+		if errors.As(err, &commandErr) && (commandErr.Code == 27 || commandErr.Code == 26) {
+			return nil
+		}
+		return err
+	}
+}
+
+func deduplicate(collection string, field string, newest bson.D) func(ctx context.Context, db *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		order := append(bson.D{{Key: field, Value: 1}}, newest...)
+		cursor, err := db.Collection(collection).Aggregate(ctx, mongo.Pipeline{
+			{{Key: "$sort", Value: order}},
+			{{Key: "$group", Value: bson.M{"_id": "$" + field, "ids": bson.M{"$push": "$_id"}}}},
+			{{Key: "$match", Value: bson.M{"ids.1": bson.M{"$exists": true}}}},
+		}, options.Aggregate().SetAllowDiskUse(true))
+		if err != nil {
+			return err
+		}
+		var groups []struct {
+			Ids []interface{} `bson:"ids"`
+		}
+		if err := cursor.All(ctx, &groups); err != nil {
+			return err
+		}
+
+		removed := int64(0)
+		for _, group := range groups {
+			result, err := db.Collection(collection).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": group.Ids[1:]}})
+			if err != nil {
+				return err
+			}
+			removed += result.DeletedCount
+		}
+		if removed > 0 {
+			log.Warn().Str("collection", collection).Str("field", field).Int64("removed", removed).Msg("remove duplicates")
+		}
+		return nil
+	}
+}
+
+func index(collection string, name string, keys bson.D, opts *options.IndexOptions) (func(ctx context.Context, db *mongo.Database) error, func(ctx context.Context, db *mongo.Database) error) {
+	if opts == nil {
+		opts = options.Index()
+	}
+	opts.SetName(name)
+	return createIndex(collection, mongo.IndexModel{Keys: keys, Options: opts}), dropIndex(collection, name)
+}
@@ -0,0 +1,83 @@
+package migration
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"picket-answersheet-service/src/config"
+)
+
+// All lists the migrations of the service against the configured collection
+// names. Append new ones with the next version, never edit or renumber one
+// that has shipped.
+func All(config config.IConfig) []Migration {
+	return []Migration{
+		newIndexMigration(1, "events_user_test_event", config.GetEventsCollection(),
+			bson.D{{"user_id", 1}, {"test_id", 1}, {"event", 1}, {"_id", 1}}, nil),
+		newIndexMigration(2, "events_session", config.GetEventsCollection(),
+			bson.D{{"session", 1}, {"_id", 1}}, nil),
+		// SyncTest used to check then insert, so concurrent syncs of a test
+		// left duplicates behind; the newest version of each test is kept
+		newUniqueMigration(3, "tests_test_id", config.GetTestsCollection(),
+			"test_id", bson.D{{"version", -1}, {"_id", -1}}),
+		newIndexMigration(4, "events_idempotency_key", config.GetEventsCollection(),
+			bson.D{{"idempotency_key", 1}}, options.Index().SetUnique(true).SetSparse(true)),
+		newIndexMigration(5, "sessions_user_test", config.GetSessionsCollection(),
+			bson.D{{"user_id", 1}, {"test_id", 1}, {"start_event_id", -1}}, nil),
+		newIndexMigration(6, "outbox_status", config.GetOutboxCollection(),
+			bson.D{{"status", 1}, {"_id", 1}}, nil),
+		newIndexMigration(7, "sessions_user_test_attempt", config.GetSessionsCollection(),
+			bson.D{{"user_id", 1}, {"test_id", 1}, {"attempt", 1}},
+			options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"attempt": bson.M{"$gt": 0}})),
+		newIndexMigration(8, "sessions_state_deadline", config.GetSessionsCollection(),
+			bson.D{{"state", 1}, {"deadline", 1}}, nil),
+		newIndexMigration(9, "sessions_test_state", config.GetSessionsCollection(),
+			bson.D{{"test_id", 1}, {"state", 1}, {"user_id", 1}}, nil),
+		newIndexMigration(10, "accommodations_test_user", config.GetAccommodationsCollection(),
+			bson.D{{"test_id", 1}, {"user_id", 1}}, options.Index().SetUnique(true)),
+		// every synced version of a test is kept, sessions are scored against
+		// the version they started with
+		newTestVersionsMigration(11, config.GetTestsCollection()),
+	}
+}
+
+// newTestVersionsMigration replaces the unique index on test_id of migration 3
+// by one on test_id and version; going down keeps the newest version of each
+// test again.
+func newTestVersionsMigration(version int, collection string) Migration {
+	up, down := index(collection, "tests_test_id_version", bson.D{{"test_id", 1}, {"version", 1}}, options.Index().SetUnique(true))
+	single := newUniqueMigration(3, "tests_test_id", collection, "test_id", bson.D{{"version", -1}, {"_id", -1}})
+	return Migration{Version: version, Name: "tests_test_id_version",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := up(ctx, db); err != nil {
+				return err
+			}
+			return single.Down(ctx, db)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if err := single.Up(ctx, db); err != nil {
+				return err
+			}
+			return down(ctx, db)
+		},
+	}
+}
+
+// newUniqueMigration removes the documents sharing a value of field, keeping
+// the first one when sorted by newest, then creates the unique index on field.
+func newUniqueMigration(version int, name string, collection string, field string, newest bson.D) Migration {
+	up, down := index(collection, name, bson.D{{field, 1}}, options.Index().SetUnique(true))
+	dedupe := deduplicate(collection, field, newest)
+	return Migration{Version: version, Name: name, Down: down, Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := dedupe(ctx, db); err != nil {
+			return err
+		}
+		return up(ctx, db)
+	}}
+}
+
+func newIndexMigration(version int, name string, collection string, keys bson.D, opts *options.IndexOptions) Migration {
+	up, down := index(collection, name, keys, opts)
+	return Migration{Version: version, Name: name, Up: up, Down: down}
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: accommodation.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entities "picket-answersheet-service/src/internal/entities"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAccommodationRepository is a mock of IAccommodationRepository interface.
+type MockIAccommodationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccommodationRepositoryMockRecorder
+}
+
+// MockIAccommodationRepositoryMockRecorder is the mock recorder for MockIAccommodationRepository.
+type MockIAccommodationRepositoryMockRecorder struct {
+	mock *MockIAccommodationRepository
+}
+
+// NewMockIAccommodationRepository creates a new mock instance.
+func NewMockIAccommodationRepository(ctrl *gomock.Controller) *MockIAccommodationRepository {
+	mock := &MockIAccommodationRepository{ctrl: ctrl}
+	mock.recorder = &MockIAccommodationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccommodationRepository) EXPECT() *MockIAccommodationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByTestIdAndUserId mocks base method.
+func (m *MockIAccommodationRepository) FindByTestIdAndUserId(ctx context.Context, testId, userId int) (*entities.Accommodation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTestIdAndUserId", ctx, testId, userId)
+	ret0, _ := ret[0].(*entities.Accommodation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTestIdAndUserId indicates an expected call of FindByTestIdAndUserId.
+func (mr *MockIAccommodationRepositoryMockRecorder) FindByTestIdAndUserId(ctx, testId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTestIdAndUserId", reflect.TypeOf((*MockIAccommodationRepository)(nil).FindByTestIdAndUserId), ctx, testId, userId)
+}
+
+// Save mocks base method.
+func (m *MockIAccommodationRepository) Save(ctx context.Context, accommodation *entities.Accommodation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, accommodation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockIAccommodationRepositoryMockRecorder) Save(ctx, accommodation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIAccommodationRepository)(nil).Save), ctx, accommodation)
+}
@@ -0,0 +1,319 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: answersheet.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entities "picket-answersheet-service/src/internal/entities"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// MockIAnswersheetRepository is a mock of IAnswersheetRepository interface.
+type MockIAnswersheetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAnswersheetRepositoryMockRecorder
+}
+
+// MockIAnswersheetRepositoryMockRecorder is the mock recorder for MockIAnswersheetRepository.
+type MockIAnswersheetRepositoryMockRecorder struct {
+	mock *MockIAnswersheetRepository
+}
+
+// NewMockIAnswersheetRepository creates a new mock instance.
+func NewMockIAnswersheetRepository(ctrl *gomock.Controller) *MockIAnswersheetRepository {
+	mock := &MockIAnswersheetRepository{ctrl: ctrl}
+	mock.recorder = &MockIAnswersheetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAnswersheetRepository) EXPECT() *MockIAnswersheetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIAnswersheetRepository) Create(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event, session, expectedVersion, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAnswersheetRepositoryMockRecorder) Create(ctx, event, session, expectedVersion, outbox interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAnswersheetRepository)(nil).Create), ctx, event, session, expectedVersion, outbox)
+}
+
+// FindActiveSessions mocks base method.
+func (m *MockIAnswersheetRepository) FindActiveSessions(ctx context.Context, testId int) ([]entities.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveSessions", ctx, testId)
+	ret0, _ := ret[0].([]entities.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveSessions indicates an expected call of FindActiveSessions.
+func (mr *MockIAnswersheetRepositoryMockRecorder) FindActiveSessions(ctx, testId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveSessions", reflect.TypeOf((*MockIAnswersheetRepository)(nil).FindActiveSessions), ctx, testId)
+}
+
+// FindAnswerByUserIdAndTestId mocks base method.
+func (m *MockIAnswersheetRepository) FindAnswerByUserIdAndTestId(ctx context.Context, userId, testId int, sessionId string) ([]entities.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAnswerByUserIdAndTestId", ctx, userId, testId, sessionId)
+	ret0, _ := ret[0].([]entities.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAnswerByUserIdAndTestId indicates an expected call of FindAnswerByUserIdAndTestId.
+func (mr *MockIAnswersheetRepositoryMockRecorder) FindAnswerByUserIdAndTestId(ctx, userId, testId, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAnswerByUserIdAndTestId", reflect.TypeOf((*MockIAnswersheetRepository)(nil).FindAnswerByUserIdAndTestId), ctx, userId, testId, sessionId)
+}
+
+// FindByIdempotencyKey mocks base method.
+func (m *MockIAnswersheetRepository) FindByIdempotencyKey(ctx context.Context, key string) (*entities.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdempotencyKey", ctx, key)
+	ret0, _ := ret[0].(*entities.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdempotencyKey indicates an expected call of FindByIdempotencyKey.
+func (mr *MockIAnswersheetRepositoryMockRecorder) FindByIdempotencyKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdempotencyKey", reflect.TypeOf((*MockIAnswersheetRepository)(nil).FindByIdempotencyKey), ctx, key)
+}
+
+// FindBySession mocks base method.
+func (m *MockIAnswersheetRepository) FindBySession(ctx context.Context, sessionId string) ([]entities.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySession", ctx, sessionId)
+	ret0, _ := ret[0].([]entities.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySession indicates an expected call of FindBySession.
+func (mr *MockIAnswersheetRepositoryMockRecorder) FindBySession(ctx, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySession", reflect.TypeOf((*MockIAnswersheetRepository)(nil).FindBySession), ctx, sessionId)
+}
+
+// FindExpiredSessions mocks base method.
+func (m *MockIAnswersheetRepository) FindExpiredSessions(ctx context.Context, now time.Time, limit int) ([]entities.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiredSessions", ctx, now, limit)
+	ret0, _ := ret[0].([]entities.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiredSessions indicates an expected call of FindExpiredSessions.
+func (mr *MockIAnswersheetRepositoryMockRecorder) FindExpiredSessions(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiredSessions", reflect.TypeOf((*MockIAnswersheetRepository)(nil).FindExpiredSessions), ctx, now, limit)
+}
+
+// FindLatestSession mocks base method.
+func (m *MockIAnswersheetRepository) FindLatestSession(ctx context.Context, userId, testId int) (*entities.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestSession", ctx, userId, testId)
+	ret0, _ := ret[0].(*entities.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestSession indicates an expected call of FindLatestSession.
+func (mr *MockIAnswersheetRepositoryMockRecorder) FindLatestSession(ctx, userId, testId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestSession", reflect.TypeOf((*MockIAnswersheetRepository)(nil).FindLatestSession), ctx, userId, testId)
+}
+
+// FindSession mocks base method.
+func (m *MockIAnswersheetRepository) FindSession(ctx context.Context, sessionId string) (*entities.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSession", ctx, sessionId)
+	ret0, _ := ret[0].(*entities.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSession indicates an expected call of FindSession.
+func (mr *MockIAnswersheetRepositoryMockRecorder) FindSession(ctx, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSession", reflect.TypeOf((*MockIAnswersheetRepository)(nil).FindSession), ctx, sessionId)
+}
+
+// ListSessionIds mocks base method.
+func (m *MockIAnswersheetRepository) ListSessionIds(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessionIds", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessionIds indicates an expected call of ListSessionIds.
+func (mr *MockIAnswersheetRepositoryMockRecorder) ListSessionIds(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessionIds", reflect.TypeOf((*MockIAnswersheetRepository)(nil).ListSessionIds), ctx)
+}
+
+// SaveSession mocks base method.
+func (m *MockIAnswersheetRepository) SaveSession(ctx context.Context, session *entities.Session, expectedVersion int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSession", ctx, session, expectedVersion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSession indicates an expected call of SaveSession.
+func (mr *MockIAnswersheetRepositoryMockRecorder) SaveSession(ctx, session, expectedVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSession", reflect.TypeOf((*MockIAnswersheetRepository)(nil).SaveSession), ctx, session, expectedVersion)
+}
+
+// MockIPublisher is a mock of IPublisher interface.
+type MockIPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPublisherMockRecorder
+}
+
+// MockIPublisherMockRecorder is the mock recorder for MockIPublisher.
+type MockIPublisherMockRecorder struct {
+	mock *MockIPublisher
+}
+
+// NewMockIPublisher creates a new mock instance.
+func NewMockIPublisher(ctrl *gomock.Controller) *MockIPublisher {
+	mock := &MockIPublisher{ctrl: ctrl}
+	mock.recorder = &MockIPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPublisher) EXPECT() *MockIPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockIPublisher) Publish(ctx context.Context, topic string, messages ...kafka.Message) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, topic}
+	for _, a := range messages {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Publish", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIPublisherMockRecorder) Publish(ctx, topic interface{}, messages ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, topic}, messages...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIPublisher)(nil).Publish), varargs...)
+}
+
+// MockITestUsecase is a mock of ITestUsecase interface.
+type MockITestUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockITestUsecaseMockRecorder
+}
+
+// MockITestUsecaseMockRecorder is the mock recorder for MockITestUsecase.
+type MockITestUsecaseMockRecorder struct {
+	mock *MockITestUsecase
+}
+
+// NewMockITestUsecase creates a new mock instance.
+func NewMockITestUsecase(ctrl *gomock.Controller) *MockITestUsecase {
+	mock := &MockITestUsecase{ctrl: ctrl}
+	mock.recorder = &MockITestUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITestUsecase) EXPECT() *MockITestUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetByTestId mocks base method.
+func (m *MockITestUsecase) GetByTestId(ctx context.Context, testId int) (*entities.Test, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTestId", ctx, testId)
+	ret0, _ := ret[0].(*entities.Test)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTestId indicates an expected call of GetByTestId.
+func (mr *MockITestUsecaseMockRecorder) GetByTestId(ctx, testId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTestId", reflect.TypeOf((*MockITestUsecase)(nil).GetByTestId), ctx, testId)
+}
+
+// GetByTestIdAndVersion mocks base method.
+func (m *MockITestUsecase) GetByTestIdAndVersion(ctx context.Context, testId, version int) (*entities.Test, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTestIdAndVersion", ctx, testId, version)
+	ret0, _ := ret[0].(*entities.Test)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTestIdAndVersion indicates an expected call of GetByTestIdAndVersion.
+func (mr *MockITestUsecaseMockRecorder) GetByTestIdAndVersion(ctx, testId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTestIdAndVersion", reflect.TypeOf((*MockITestUsecase)(nil).GetByTestIdAndVersion), ctx, testId, version)
+}
+
+// MockIAccommodationUsecase is a mock of IAccommodationUsecase interface.
+type MockIAccommodationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccommodationUsecaseMockRecorder
+}
+
+// MockIAccommodationUsecaseMockRecorder is the mock recorder for MockIAccommodationUsecase.
+type MockIAccommodationUsecaseMockRecorder struct {
+	mock *MockIAccommodationUsecase
+}
+
+// NewMockIAccommodationUsecase creates a new mock instance.
+func NewMockIAccommodationUsecase(ctrl *gomock.Controller) *MockIAccommodationUsecase {
+	mock := &MockIAccommodationUsecase{ctrl: ctrl}
+	mock.recorder = &MockIAccommodationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccommodationUsecase) EXPECT() *MockIAccommodationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetAccommodation mocks base method.
+func (m *MockIAccommodationUsecase) GetAccommodation(ctx context.Context, testId, userId int) (*entities.Accommodation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccommodation", ctx, testId, userId)
+	ret0, _ := ret[0].(*entities.Accommodation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccommodation indicates an expected call of GetAccommodation.
+func (mr *MockIAccommodationUsecaseMockRecorder) GetAccommodation(ctx, testId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccommodation", reflect.TypeOf((*MockIAccommodationUsecase)(nil).GetAccommodation), ctx, testId, userId)
+}
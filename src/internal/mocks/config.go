@@ -0,0 +1,429 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: declare.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	mongo "go.mongodb.org/mongo-driver/mongo"
+	gorm "gorm.io/gorm"
+)
+
+// MockIConfig is a mock of IConfig interface.
+type MockIConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockIConfigMockRecorder
+}
+
+// MockIConfigMockRecorder is the mock recorder for MockIConfig.
+type MockIConfigMockRecorder struct {
+	mock *MockIConfig
+}
+
+// NewMockIConfig creates a new mock instance.
+func NewMockIConfig(ctrl *gomock.Controller) *MockIConfig {
+	mock := &MockIConfig{ctrl: ctrl}
+	mock.recorder = &MockIConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIConfig) EXPECT() *MockIConfigMockRecorder {
+	return m.recorder
+}
+
+// GetAccommodationsCollection mocks base method.
+func (m *MockIConfig) GetAccommodationsCollection() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccommodationsCollection")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetAccommodationsCollection indicates an expected call of GetAccommodationsCollection.
+func (mr *MockIConfigMockRecorder) GetAccommodationsCollection() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccommodationsCollection", reflect.TypeOf((*MockIConfig)(nil).GetAccommodationsCollection))
+}
+
+// GetAuthSecret mocks base method.
+func (m *MockIConfig) GetAuthSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetAuthSecret indicates an expected call of GetAuthSecret.
+func (mr *MockIConfigMockRecorder) GetAuthSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthSecret", reflect.TypeOf((*MockIConfig)(nil).GetAuthSecret))
+}
+
+// GetDispatcherParkTimeout mocks base method.
+func (m *MockIConfig) GetDispatcherParkTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDispatcherParkTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetDispatcherParkTimeout indicates an expected call of GetDispatcherParkTimeout.
+func (mr *MockIConfigMockRecorder) GetDispatcherParkTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispatcherParkTimeout", reflect.TypeOf((*MockIConfig)(nil).GetDispatcherParkTimeout))
+}
+
+// GetDispatcherReorderWindow mocks base method.
+func (m *MockIConfig) GetDispatcherReorderWindow() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDispatcherReorderWindow")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetDispatcherReorderWindow indicates an expected call of GetDispatcherReorderWindow.
+func (mr *MockIConfigMockRecorder) GetDispatcherReorderWindow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispatcherReorderWindow", reflect.TypeOf((*MockIConfig)(nil).GetDispatcherReorderWindow))
+}
+
+// GetDispatcherWorkers mocks base method.
+func (m *MockIConfig) GetDispatcherWorkers() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDispatcherWorkers")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetDispatcherWorkers indicates an expected call of GetDispatcherWorkers.
+func (mr *MockIConfigMockRecorder) GetDispatcherWorkers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispatcherWorkers", reflect.TypeOf((*MockIConfig)(nil).GetDispatcherWorkers))
+}
+
+// GetEventsCollection mocks base method.
+func (m *MockIConfig) GetEventsCollection() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsCollection")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetEventsCollection indicates an expected call of GetEventsCollection.
+func (mr *MockIConfigMockRecorder) GetEventsCollection() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsCollection", reflect.TypeOf((*MockIConfig)(nil).GetEventsCollection))
+}
+
+// GetGrpcPort mocks base method.
+func (m *MockIConfig) GetGrpcPort() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrpcPort")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetGrpcPort indicates an expected call of GetGrpcPort.
+func (mr *MockIConfigMockRecorder) GetGrpcPort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrpcPort", reflect.TypeOf((*MockIConfig)(nil).GetGrpcPort))
+}
+
+// GetKafkaAnswerWorkers mocks base method.
+func (m *MockIConfig) GetKafkaAnswerWorkers() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaAnswerWorkers")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetKafkaAnswerWorkers indicates an expected call of GetKafkaAnswerWorkers.
+func (mr *MockIConfigMockRecorder) GetKafkaAnswerWorkers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaAnswerWorkers", reflect.TypeOf((*MockIConfig)(nil).GetKafkaAnswerWorkers))
+}
+
+// GetKafkaBroker mocks base method.
+func (m *MockIConfig) GetKafkaBroker() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaBroker")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetKafkaBroker indicates an expected call of GetKafkaBroker.
+func (mr *MockIConfigMockRecorder) GetKafkaBroker() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaBroker", reflect.TypeOf((*MockIConfig)(nil).GetKafkaBroker))
+}
+
+// GetKafkaEventWorkers mocks base method.
+func (m *MockIConfig) GetKafkaEventWorkers() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaEventWorkers")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetKafkaEventWorkers indicates an expected call of GetKafkaEventWorkers.
+func (mr *MockIConfigMockRecorder) GetKafkaEventWorkers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaEventWorkers", reflect.TypeOf((*MockIConfig)(nil).GetKafkaEventWorkers))
+}
+
+// GetKafkaPublishBatchSize mocks base method.
+func (m *MockIConfig) GetKafkaPublishBatchSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaPublishBatchSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetKafkaPublishBatchSize indicates an expected call of GetKafkaPublishBatchSize.
+func (mr *MockIConfigMockRecorder) GetKafkaPublishBatchSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaPublishBatchSize", reflect.TypeOf((*MockIConfig)(nil).GetKafkaPublishBatchSize))
+}
+
+// GetKafkaPublishBatchTimeout mocks base method.
+func (m *MockIConfig) GetKafkaPublishBatchTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaPublishBatchTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetKafkaPublishBatchTimeout indicates an expected call of GetKafkaPublishBatchTimeout.
+func (mr *MockIConfigMockRecorder) GetKafkaPublishBatchTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaPublishBatchTimeout", reflect.TypeOf((*MockIConfig)(nil).GetKafkaPublishBatchTimeout))
+}
+
+// GetKafkaPublishQueueSize mocks base method.
+func (m *MockIConfig) GetKafkaPublishQueueSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaPublishQueueSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetKafkaPublishQueueSize indicates an expected call of GetKafkaPublishQueueSize.
+func (mr *MockIConfigMockRecorder) GetKafkaPublishQueueSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaPublishQueueSize", reflect.TypeOf((*MockIConfig)(nil).GetKafkaPublishQueueSize))
+}
+
+// GetKafkaStartWorkers mocks base method.
+func (m *MockIConfig) GetKafkaStartWorkers() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaStartWorkers")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetKafkaStartWorkers indicates an expected call of GetKafkaStartWorkers.
+func (mr *MockIConfigMockRecorder) GetKafkaStartWorkers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaStartWorkers", reflect.TypeOf((*MockIConfig)(nil).GetKafkaStartWorkers))
+}
+
+// GetKafkaSubmitWorkers mocks base method.
+func (m *MockIConfig) GetKafkaSubmitWorkers() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKafkaSubmitWorkers")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetKafkaSubmitWorkers indicates an expected call of GetKafkaSubmitWorkers.
+func (mr *MockIConfigMockRecorder) GetKafkaSubmitWorkers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaSubmitWorkers", reflect.TypeOf((*MockIConfig)(nil).GetKafkaSubmitWorkers))
+}
+
+// GetMaxClockSkew mocks base method.
+func (m *MockIConfig) GetMaxClockSkew() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxClockSkew")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetMaxClockSkew indicates an expected call of GetMaxClockSkew.
+func (mr *MockIConfigMockRecorder) GetMaxClockSkew() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxClockSkew", reflect.TypeOf((*MockIConfig)(nil).GetMaxClockSkew))
+}
+
+// GetMongo mocks base method.
+func (m *MockIConfig) GetMongo() *mongo.Client {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMongo")
+	ret0, _ := ret[0].(*mongo.Client)
+	return ret0
+}
+
+// GetMongo indicates an expected call of GetMongo.
+func (mr *MockIConfigMockRecorder) GetMongo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMongo", reflect.TypeOf((*MockIConfig)(nil).GetMongo))
+}
+
+// GetMongoDatabase mocks base method.
+func (m *MockIConfig) GetMongoDatabase() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMongoDatabase")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetMongoDatabase indicates an expected call of GetMongoDatabase.
+func (mr *MockIConfigMockRecorder) GetMongoDatabase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMongoDatabase", reflect.TypeOf((*MockIConfig)(nil).GetMongoDatabase))
+}
+
+// GetOutboxBatchSize mocks base method.
+func (m *MockIConfig) GetOutboxBatchSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxBatchSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetOutboxBatchSize indicates an expected call of GetOutboxBatchSize.
+func (mr *MockIConfigMockRecorder) GetOutboxBatchSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxBatchSize", reflect.TypeOf((*MockIConfig)(nil).GetOutboxBatchSize))
+}
+
+// GetOutboxCollection mocks base method.
+func (m *MockIConfig) GetOutboxCollection() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxCollection")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetOutboxCollection indicates an expected call of GetOutboxCollection.
+func (mr *MockIConfigMockRecorder) GetOutboxCollection() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxCollection", reflect.TypeOf((*MockIConfig)(nil).GetOutboxCollection))
+}
+
+// GetOutboxPollInterval mocks base method.
+func (m *MockIConfig) GetOutboxPollInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxPollInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetOutboxPollInterval indicates an expected call of GetOutboxPollInterval.
+func (mr *MockIConfigMockRecorder) GetOutboxPollInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxPollInterval", reflect.TypeOf((*MockIConfig)(nil).GetOutboxPollInterval))
+}
+
+// GetPostgres mocks base method.
+func (m *MockIConfig) GetPostgres() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostgres")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// GetPostgres indicates an expected call of GetPostgres.
+func (mr *MockIConfigMockRecorder) GetPostgres() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostgres", reflect.TypeOf((*MockIConfig)(nil).GetPostgres))
+}
+
+// GetSessionsCollection mocks base method.
+func (m *MockIConfig) GetSessionsCollection() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionsCollection")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSessionsCollection indicates an expected call of GetSessionsCollection.
+func (mr *MockIConfigMockRecorder) GetSessionsCollection() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionsCollection", reflect.TypeOf((*MockIConfig)(nil).GetSessionsCollection))
+}
+
+// GetStorageBackend mocks base method.
+func (m *MockIConfig) GetStorageBackend() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageBackend")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetStorageBackend indicates an expected call of GetStorageBackend.
+func (mr *MockIConfigMockRecorder) GetStorageBackend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageBackend", reflect.TypeOf((*MockIConfig)(nil).GetStorageBackend))
+}
+
+// GetSweeperBatchSize mocks base method.
+func (m *MockIConfig) GetSweeperBatchSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSweeperBatchSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetSweeperBatchSize indicates an expected call of GetSweeperBatchSize.
+func (mr *MockIConfigMockRecorder) GetSweeperBatchSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSweeperBatchSize", reflect.TypeOf((*MockIConfig)(nil).GetSweeperBatchSize))
+}
+
+// GetSweeperInterval mocks base method.
+func (m *MockIConfig) GetSweeperInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSweeperInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetSweeperInterval indicates an expected call of GetSweeperInterval.
+func (mr *MockIConfigMockRecorder) GetSweeperInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSweeperInterval", reflect.TypeOf((*MockIConfig)(nil).GetSweeperInterval))
+}
+
+// GetTestsCollection mocks base method.
+func (m *MockIConfig) GetTestsCollection() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTestsCollection")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTestsCollection indicates an expected call of GetTestsCollection.
+func (mr *MockIConfigMockRecorder) GetTestsCollection() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestsCollection", reflect.TypeOf((*MockIConfig)(nil).GetTestsCollection))
+}
+
+// GetWatchInterval mocks base method.
+func (m *MockIConfig) GetWatchInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetWatchInterval indicates an expected call of GetWatchInterval.
+func (mr *MockIConfigMockRecorder) GetWatchInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchInterval", reflect.TypeOf((*MockIConfig)(nil).GetWatchInterval))
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: test.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entities "picket-answersheet-service/src/internal/entities"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockITestRepository is a mock of ITestRepository interface.
+type MockITestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockITestRepositoryMockRecorder
+}
+
+// MockITestRepositoryMockRecorder is the mock recorder for MockITestRepository.
+type MockITestRepositoryMockRecorder struct {
+	mock *MockITestRepository
+}
+
+// NewMockITestRepository creates a new mock instance.
+func NewMockITestRepository(ctrl *gomock.Controller) *MockITestRepository {
+	mock := &MockITestRepository{ctrl: ctrl}
+	mock.recorder = &MockITestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITestRepository) EXPECT() *MockITestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockITestRepository) Create(ctx context.Context, test *entities.Test) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, test)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockITestRepositoryMockRecorder) Create(ctx, test interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockITestRepository)(nil).Create), ctx, test)
+}
+
+// FindByTestId mocks base method.
+func (m *MockITestRepository) FindByTestId(ctx context.Context, testId int) (*entities.Test, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTestId", ctx, testId)
+	ret0, _ := ret[0].(*entities.Test)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTestId indicates an expected call of FindByTestId.
+func (mr *MockITestRepositoryMockRecorder) FindByTestId(ctx, testId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTestId", reflect.TypeOf((*MockITestRepository)(nil).FindByTestId), ctx, testId)
+}
+
+// FindByTestIdAndVersion mocks base method.
+func (m *MockITestRepository) FindByTestIdAndVersion(ctx context.Context, testId, version int) (*entities.Test, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTestIdAndVersion", ctx, testId, version)
+	ret0, _ := ret[0].(*entities.Test)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTestIdAndVersion indicates an expected call of FindByTestIdAndVersion.
+func (mr *MockITestRepositoryMockRecorder) FindByTestIdAndVersion(ctx, testId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTestIdAndVersion", reflect.TypeOf((*MockITestRepository)(nil).FindByTestIdAndVersion), ctx, testId, version)
+}
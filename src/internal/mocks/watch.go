@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: watch.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entities "picket-answersheet-service/src/internal/entities"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIHub is a mock of IHub interface.
+type MockIHub struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHubMockRecorder
+}
+
+// MockIHubMockRecorder is the mock recorder for MockIHub.
+type MockIHubMockRecorder struct {
+	mock *MockIHub
+}
+
+// NewMockIHub creates a new mock instance.
+func NewMockIHub(ctrl *gomock.Controller) *MockIHub {
+	mock := &MockIHub{ctrl: ctrl}
+	mock.recorder = &MockIHubMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHub) EXPECT() *MockIHubMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockIHub) Publish(update entities.SessionUpdate) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", update)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIHubMockRecorder) Publish(update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIHub)(nil).Publish), update)
+}
+
+// Subscribe mocks base method.
+func (m *MockIHub) Subscribe(userId, testId int) (<-chan entities.SessionUpdate, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userId, testId)
+	ret0, _ := ret[0].(<-chan entities.SessionUpdate)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockIHubMockRecorder) Subscribe(userId, testId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockIHub)(nil).Subscribe), userId, testId)
+}
@@ -0,0 +1,224 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/kafka-go"
+	"picket-answersheet-service/src/config"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrPublisherClosed = errors.New("publisher closed")
+
+type delivery struct {
+	message kafka.Message
+	done    chan error
+}
+
+// messageWriter is the part of kafka.Writer the publisher uses.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+type topicWriter struct {
+	topic  string
+	writer messageWriter
+	queue  chan delivery
+}
+
+// publisher keeps one long-lived kafka.Writer per topic. Messages are queued
+// into a bounded channel and flushed in batches by a background loop; Publish
+// blocks while the queue is full and returns once the broker has acknowledged
+// the batch the message was written in.
+type publisher struct {
+	brokers      []string
+	batchSize    int
+	batchTimeout time.Duration
+	queueSize    int
+
+	// newWriter opens the writer of a topic
+	newWriter func(topic string) messageWriter
+
+	// mu guards topics and isClosed, so no topic loop is added to wg once
+	// Close has started waiting on it
+	mu       sync.Mutex
+	topics   map[string]*topicWriter
+	isClosed bool
+	closing  chan struct{}
+	closed   chan struct{}
+	once     sync.Once
+	wg       sync.WaitGroup
+}
+
+func NewPublisher(ctx context.Context, config config.IConfig) *publisher {
+	p := &publisher{
+		brokers:      strings.Split(config.GetKafkaBroker(), ","),
+		batchSize:    config.GetKafkaPublishBatchSize(),
+		batchTimeout: config.GetKafkaPublishBatchTimeout(),
+		queueSize:    config.GetKafkaPublishQueueSize(),
+		topics:       make(map[string]*topicWriter),
+		closing:      make(chan struct{}),
+		closed:       make(chan struct{}),
+	}
+	p.newWriter = func(topic string) messageWriter {
+		return &kafka.Writer{
+			Addr:                   kafka.TCP(p.brokers...),
+			Topic:                  topic,
+			AllowAutoTopicCreation: true,
+			Balancer:               &kafka.Hash{},
+			BatchSize:              p.batchSize,
+			BatchTimeout:           time.Millisecond,
+			RequiredAcks:           kafka.RequireAll,
+		}
+	}
+	if p.batchSize <= 0 {
+		p.batchSize = 1
+	}
+	if p.queueSize <= 0 {
+		p.queueSize = p.batchSize
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := p.Close(); err != nil {
+			log.Error().Err(err).Send()
+		}
+	}()
+
+	return p
+}
+
+func (p *publisher) Publish(ctx context.Context, topic string, messages ...kafka.Message) error {
+	t, err := p.topic(topic)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]delivery, len(messages))
+	for index, message := range messages {
+		deliveries[index] = delivery{message: message, done: make(chan error, 1)}
+		select {
+		case t.queue <- deliveries[index]:
+		case <-p.closing:
+			return ErrPublisherClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for _, item := range deliveries {
+		select {
+		case err := <-item.done:
+			if err != nil {
+				return err
+			}
+		case <-p.closed:
+			return ErrPublisherClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (p *publisher) Close() error {
+	p.once.Do(func() {
+		p.mu.Lock()
+		p.isClosed = true
+		close(p.closing)
+		p.mu.Unlock()
+
+		p.wg.Wait()
+		close(p.closed)
+	})
+	return nil
+}
+
+func (p *publisher) topic(name string) (*topicWriter, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.isClosed {
+		return nil, ErrPublisherClosed
+	}
+
+	if t, ok := p.topics[name]; ok {
+		return t, nil
+	}
+
+	t := &topicWriter{
+		topic:  name,
+		writer: p.newWriter(name),
+		queue:  make(chan delivery, p.queueSize),
+	}
+	p.topics[name] = t
+
+	p.wg.Add(1)
+	go p.run(t)
+
+	return t, nil
+}
+
+func (p *publisher) run(t *topicWriter) {
+	defer p.wg.Done()
+
+	batch := make([]delivery, 0, p.batchSize)
+	var timeout <-chan time.Time
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		messages := make([]kafka.Message, len(batch))
+		for index, item := range batch {
+			messages[index] = item.message
+		}
+		err := t.writer.WriteMessages(context.Background(), messages...)
+		if err != nil {
+			log.Error().Err(err).Str("topic", t.topic).Int("size", len(batch)).Msg("publish batch")
+		}
+		for _, item := range batch {
+			item.done <- err
+		}
+		batch = batch[:0]
+		timeout = nil
+	}
+
+	for {
+		select {
+		case item := <-t.queue:
+			batch = append(batch, item)
+			if len(batch) == 1 {
+				timeout = time.After(p.batchTimeout)
+			}
+			if len(batch) >= p.batchSize {
+				flush()
+			}
+		case <-timeout:
+			flush()
+		case <-p.closing:
+		drain:
+			for {
+				select {
+				case item := <-t.queue:
+					batch = append(batch, item)
+					if len(batch) >= p.batchSize {
+						flush()
+					}
+				default:
+					break drain
+				}
+			}
+			flush()
+			if err := t.writer.Close(); err != nil {
+				log.Error().Err(err).Str("topic", t.topic).Send()
+			}
+			return
+		}
+	}
+}
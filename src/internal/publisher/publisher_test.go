@@ -0,0 +1,204 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"github.com/segmentio/kafka-go"
+	"picket-answersheet-service/src/config"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeWriter records the batches written to it. Writes block on release when
+// it is set, so a test can hold a batch in flight.
+type fakeWriter struct {
+	mu      sync.Mutex
+	batches [][]kafka.Message
+	err     error
+	release chan struct{}
+	closed  bool
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, messages ...kafka.Message) error {
+	if w.release != nil {
+		<-w.release
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.batches = append(w.batches, messages)
+	return w.err
+}
+
+func (w *fakeWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	return nil
+}
+
+func (w *fakeWriter) sizes() []int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	result := make([]int, len(w.batches))
+	for index, batch := range w.batches {
+		result[index] = len(batch)
+	}
+	return result
+}
+
+func (w *fakeWriter) written() int {
+	total := 0
+	for _, size := range w.sizes() {
+		total += size
+	}
+	return total
+}
+
+// publisherConfig answers the settings the publisher reads.
+type publisherConfig struct {
+	config.IConfig
+	batchSize    int
+	batchTimeout time.Duration
+}
+
+func (c publisherConfig) GetKafkaBroker() string                     { return "localhost:9092" }
+func (c publisherConfig) GetKafkaPublishBatchSize() int              { return c.batchSize }
+func (c publisherConfig) GetKafkaPublishBatchTimeout() time.Duration { return c.batchTimeout }
+func (c publisherConfig) GetKafkaPublishQueueSize() int              { return 0 }
+
+func newTestPublisher(t *testing.T, writer *fakeWriter, batchSize int, batchTimeout time.Duration) *publisher {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	p := NewPublisher(ctx, publisherConfig{batchSize: batchSize, batchTimeout: batchTimeout})
+	p.newWriter = func(topic string) messageWriter { return writer }
+	return p
+}
+
+func messages(count int) []kafka.Message {
+	result := make([]kafka.Message, count)
+	for index := range result {
+		result[index] = kafka.Message{Value: []byte{byte(index)}}
+	}
+	return result
+}
+
+func TestPublisher_Batching(t *testing.T) {
+	writer := &fakeWriter{}
+	p := newTestPublisher(t, writer, 3, time.Hour)
+
+	if err := p.Publish(context.Background(), "topic", messages(6)...); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if got := writer.sizes(); len(got) != 2 || got[0] != 3 || got[1] != 3 {
+		t.Errorf("batches = %v, want [3 3]", got)
+	}
+}
+
+func TestPublisher_BatchTimeout(t *testing.T) {
+	writer := &fakeWriter{}
+	p := newTestPublisher(t, writer, 10, 10*time.Millisecond)
+
+	if err := p.Publish(context.Background(), "topic", messages(2)...); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if got := writer.sizes(); len(got) != 1 || got[0] != 2 {
+		t.Errorf("batches = %v, want [2]", got)
+	}
+}
+
+func TestPublisher_BlocksUntilAck(t *testing.T) {
+	writer := &fakeWriter{release: make(chan struct{})}
+	p := newTestPublisher(t, writer, 1, time.Hour)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.Publish(context.Background(), "topic", messages(1)...)
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("Publish() returned %v before the batch was acknowledged", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(writer.release)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Publish() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Publish() did not return after the ack")
+	}
+}
+
+func TestPublisher_WriteError(t *testing.T) {
+	errBroker := errors.New("broker down")
+	writer := &fakeWriter{err: errBroker}
+	p := newTestPublisher(t, writer, 1, time.Hour)
+
+	if err := p.Publish(context.Background(), "topic", messages(1)...); !errors.Is(err, errBroker) {
+		t.Errorf("Publish() error = %v, want %v", err, errBroker)
+	}
+}
+
+func TestPublisher_CloseDrainsQueue(t *testing.T) {
+	writer := &fakeWriter{}
+	p := newTestPublisher(t, writer, 10, time.Hour)
+
+	// queue a partial batch that only Close can flush
+	topic, err := p.topic("topic")
+	if err != nil {
+		t.Fatalf("topic() error = %v", err)
+	}
+	deliveries := make([]delivery, 4)
+	for index, message := range messages(len(deliveries)) {
+		deliveries[index] = delivery{message: message, done: make(chan error, 1)}
+		topic.queue <- deliveries[index]
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	for index, item := range deliveries {
+		select {
+		case err := <-item.done:
+			if err != nil {
+				t.Errorf("delivery %d error = %v", index, err)
+			}
+		default:
+			t.Errorf("delivery %d not acknowledged", index)
+		}
+	}
+	if got := writer.written(); got != 4 {
+		t.Errorf("written = %d, want 4", got)
+	}
+	if !writer.closed {
+		t.Error("writer not closed")
+	}
+	if err := p.Publish(context.Background(), "topic", messages(1)...); !errors.Is(err, ErrPublisherClosed) {
+		t.Errorf("Publish() after Close error = %v, want %v", err, ErrPublisherClosed)
+	}
+}
+
+func TestPublisher_CloseConcurrentTopics(t *testing.T) {
+	writer := &fakeWriter{}
+	p := newTestPublisher(t, writer, 1, time.Hour)
+
+	var wg sync.WaitGroup
+	for index := 0; index < 20; index++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			err := p.Publish(context.Background(), string(rune('a'+index)), messages(1)...)
+			if err != nil && !errors.Is(err, ErrPublisherClosed) {
+				t.Errorf("Publish() error = %v", err)
+			}
+		}(index)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	wg.Wait()
+}
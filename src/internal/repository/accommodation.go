@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/entities"
+)
+
+type accommodationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAccommodationRepository(config config.IConfig) *accommodationRepository {
+	database := config.GetMongo().Database(config.GetMongoDatabase())
+	return &accommodationRepository{collection: database.Collection(config.GetAccommodationsCollection())}
+}
+
+func (r *accommodationRepository) Save(ctx context.Context, accommodation *entities.Accommodation) error {
+	filter := bson.M{"test_id": accommodation.TestId, "user_id": accommodation.UserId}
+	_, err := r.collection.ReplaceOne(ctx, filter, accommodation, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *accommodationRepository) FindByTestIdAndUserId(ctx context.Context, testId int, userId int) (*entities.Accommodation, error) {
+	result := r.collection.FindOne(ctx, bson.M{"test_id": testId, "user_id": userId})
+	if result.Err() != nil {
+		return nil, mapError(result.Err())
+	}
+	var accommodation entities.Accommodation
+	if err := result.Decode(&accommodation); err != nil {
+		return nil, err
+	}
+	return &accommodation, nil
+}
@@ -5,21 +5,54 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"picket-answersheet-service/src/config"
 	"picket-answersheet-service/src/internal/entities"
+	"time"
 )
 
 type answersheetRepository struct {
-	mongo *mongo.Client
+	mongo    *mongo.Client
+	events   *mongo.Collection
+	sessions *mongo.Collection
+	outbox   *mongo.Collection
 }
 
-func NewAnswersheetRepository(mongo *mongo.Client) *answersheetRepository {
-	return &answersheetRepository{mongo: mongo}
+func NewAnswersheetRepository(config config.IConfig) *answersheetRepository {
+	database := config.GetMongo().Database(config.GetMongoDatabase())
+	return &answersheetRepository{
+		mongo:    config.GetMongo(),
+		events:   database.Collection(config.GetEventsCollection()),
+		sessions: database.Collection(config.GetSessionsCollection()),
+		outbox:   database.Collection(config.GetOutboxCollection()),
+	}
 }
 
-func (r *answersheetRepository) Create(ctx context.Context, event *entities.Event) error {
-	collection := r.mongo.Database("picket").Collection("events")
+// Create stores the event together with the session projection it produced
+// and, when given, its outbox row in a single transaction so neither the read
+// model nor the notification can drift from the event log. The stored session
+// must still be at expectedVersion, 0 meaning it must not exist yet.
+func (r *answersheetRepository) Create(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+	transaction, err := r.mongo.StartSession()
+	if err != nil {
+		return err
+	}
+	defer transaction.EndSession(ctx)
 
-	_, err := collection.InsertOne(ctx, event)
+	_, err = transaction.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		if _, err := r.events.InsertOne(sc, event); err != nil {
+			return nil, err
+		}
+		if err := r.swapSession(sc, session, expectedVersion); err != nil {
+			return nil, err
+		}
+		if outbox == nil {
+			return nil, nil
+		}
+		if _, err := r.outbox.InsertOne(sc, outbox); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
 	if err != nil {
 		return err
 	}
@@ -27,15 +60,29 @@ func (r *answersheetRepository) Create(ctx context.Context, event *entities.Even
 	return nil
 }
 
-func (r *answersheetRepository) FindByStatusEnd(ctx context.Context, userId int, testId int) (*entities.Event, error) {
-	filter := bson.M{
-		"event":   entities.END,
-		"user_id": userId,
-		"test_id": testId,
+func (r *answersheetRepository) swapSession(ctx context.Context, session *entities.Session, expectedVersion int) error {
+	if expectedVersion == 0 {
+		_, err := r.sessions.InsertOne(ctx, session)
+		if mongo.IsDuplicateKeyError(err) {
+			return entities.ErrVersionConflict
+		}
+		return err
+	}
+
+	result, err := r.sessions.ReplaceOne(ctx, bson.M{"_id": session.SessionId, "version": expectedVersion}, session)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return entities.ErrVersionConflict
 	}
-	resp := r.mongo.Database("picket").Collection("events").FindOne(ctx, filter)
+	return nil
+}
+
+func (r *answersheetRepository) FindByIdempotencyKey(ctx context.Context, key string) (*entities.Event, error) {
+	resp := r.events.FindOne(ctx, bson.M{"idempotency_key": key})
 	if resp.Err() != nil {
-		return nil, resp.Err()
+		return nil, mapError(resp.Err())
 	}
 	var result entities.Event
 	if err := resp.Decode(&result); err != nil {
@@ -44,17 +91,9 @@ func (r *answersheetRepository) FindByStatusEnd(ctx context.Context, userId int,
 	return &result, nil
 }
 
-func (r *answersheetRepository) GetLatestEventWithLimit(ctx context.Context, userId int, testId int, limit int) ([]entities.Event, error) {
-	filter := bson.D{
-		{
-			"$and", bson.A{
-				bson.D{{"user_id", userId}},
-				bson.D{{"test_id", testId}},
-			},
-		},
-	}
-	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.D{{"_id", -1}})
-	cursor, err := r.mongo.Database("picket").Collection("events").Find(ctx, filter, opts)
+func (r *answersheetRepository) FindBySession(ctx context.Context, sessionId string) ([]entities.Event, error) {
+	opts := options.Find().SetSort(bson.D{{"_id", 1}})
+	cursor, err := r.events.Find(ctx, bson.M{"session": sessionId}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -67,47 +106,117 @@ func (r *answersheetRepository) GetLatestEventWithLimit(ctx context.Context, use
 	return result, nil
 }
 
-func (r *answersheetRepository) GetLatestEvent(ctx context.Context, userId int, testId int) ([]entities.Event, error) {
-	return r.GetLatestEventWithLimit(ctx, userId, testId, 2)
+// SaveSession writes a session without an event, under the same version check
+// as Create.
+func (r *answersheetRepository) SaveSession(ctx context.Context, session *entities.Session, expectedVersion int) error {
+	return r.swapSession(ctx, session, expectedVersion)
 }
 
-func (r *answersheetRepository) GetLatestStartEvent(ctx context.Context, userId int, testId int) (*entities.Event, error) {
-	filter := bson.D{
-		{
-			"$and", bson.A{
-				bson.D{{"user_id", userId}},
-				bson.D{{"test_id", testId}},
-				bson.D{{"event", entities.START}},
-			},
-		},
-	}
-	opts := options.FindOne().SetSort(bson.D{{"_id", -1}})
-	resp := r.mongo.Database("picket").Collection("events").FindOne(ctx, filter, opts)
+func (r *answersheetRepository) FindSession(ctx context.Context, sessionId string) (*entities.Session, error) {
+	resp := r.sessions.FindOne(ctx, bson.M{"_id": sessionId})
 	if resp.Err() != nil {
-		return nil, resp.Err()
+		return nil, mapError(resp.Err())
 	}
-	var result entities.Event
-	err := resp.Decode(&result)
-	if err != nil {
+	var result entities.Session
+	if err := resp.Decode(&result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+func (r *answersheetRepository) FindLatestSession(ctx context.Context, userId int, testId int) (*entities.Session, error) {
+	filter := bson.M{
+		"user_id": userId,
+		"test_id": testId,
+	}
+	opts := options.FindOne().SetSort(bson.D{{"start_event_id", -1}})
+	resp := r.sessions.FindOne(ctx, filter, opts)
+	if resp.Err() != nil {
+		return nil, mapError(resp.Err())
+	}
+	var result entities.Session
+	if err := resp.Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *answersheetRepository) FindExpiredSessions(ctx context.Context, now time.Time, limit int) ([]entities.Session, error) {
+	filter := bson.M{
+		"state":    entities.SESSION_IN_PROGRESS,
+		"deadline": bson.M{"$lte": now},
+	}
+	opts := options.Find().SetSort(bson.D{{"deadline", 1}}).SetLimit(int64(limit))
+	cursor, err := r.sessions.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]entities.Session, 0)
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *answersheetRepository) FindActiveSessions(ctx context.Context, testId int) ([]entities.Session, error) {
+	filter := bson.M{
+		"test_id": testId,
+		"state":   bson.M{"$in": []string{entities.SESSION_IN_PROGRESS, entities.SESSION_PAUSED}},
+	}
+	opts := options.Find().SetSort(bson.D{{"user_id", 1}})
+	cursor, err := r.sessions.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]entities.Session, 0)
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *answersheetRepository) ListSessionIds(ctx context.Context) ([]string, error) {
+	values, err := r.events.Distinct(ctx, "session", bson.M{"event": entities.START})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(string); ok && id != "" {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+// FindAnswerByUserIdAndTestId keeps the last answer of every question stored
+// before the END of the session, if any.
 func (r *answersheetRepository) FindAnswerByUserIdAndTestId(ctx context.Context, userId int, testId int, sessionId string) ([]entities.Event, error) {
 
 	type Field struct {
 		Id     int    `bson:"_id,omitempty"`
 		Answer string `bson:"answer,omitempty"`
 	}
+	match := bson.M{"user_id": userId, "test_id": testId, "event": entities.ANSWER, "session": sessionId}
+	end := r.events.FindOne(ctx, bson.M{"session": sessionId, "event": entities.END}, options.FindOne().SetSort(bson.D{{"_id", 1}}))
+	if end.Err() == nil {
+		var event entities.Event
+		if err := end.Decode(&event); err != nil {
+			return nil, err
+		}
+		match["_id"] = bson.M{"$lt": event.Id}
+	} else if end.Err() != mongo.ErrNoDocuments {
+		return nil, end.Err()
+	}
 	filter := bson.A{
-		bson.M{"$match": bson.M{"user_id": userId, "test_id": testId, "event": entities.ANSWER, "session": sessionId}},
+		bson.M{"$match": match},
+		bson.M{"$sort": bson.M{"_id": 1}},
 		bson.M{"$group": bson.M{
 			"_id":    "$question_id",
 			"answer": bson.M{"$last": "$answer"},
 		}},
 	}
-	resp, err := r.mongo.Database("picket").Collection("events").Aggregate(ctx, filter)
+	resp, err := r.events.Aggregate(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
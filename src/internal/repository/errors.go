@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"picket-answersheet-service/src/internal/entities"
+)
+
+func mapError(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return entities.ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,33 @@
+package memory
+
+import (
+	"context"
+	"picket-answersheet-service/src/internal/entities"
+)
+
+type accommodationRepository struct {
+	store *store
+}
+
+func NewAccommodationRepository(store *store) *accommodationRepository {
+	return &accommodationRepository{store: store}
+}
+
+func (r *accommodationRepository) Save(ctx context.Context, accommodation *entities.Accommodation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.accommodations[accommodationKey{accommodation.TestId, accommodation.UserId}] = *accommodation
+	return nil
+}
+
+func (r *accommodationRepository) FindByTestIdAndUserId(ctx context.Context, testId int, userId int) (*entities.Accommodation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	accommodation, ok := r.store.accommodations[accommodationKey{testId, userId}]
+	if !ok {
+		return nil, entities.ErrNotFound
+	}
+	return &accommodation, nil
+}
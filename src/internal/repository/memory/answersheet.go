@@ -0,0 +1,227 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/entities"
+	"sort"
+	"time"
+)
+
+type answersheetRepository struct {
+	store *store
+}
+
+func NewAnswersheetRepository(store *store) *answersheetRepository {
+	return &answersheetRepository{store: store}
+}
+
+// Create checks the stored session against expectedVersion, and a new session
+// against the attempts already taken, before writing anything.
+func (r *answersheetRepository) Create(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkVersion(session, expectedVersion); err != nil {
+		return err
+	}
+
+	if event.IdempotencyKey != "" {
+		if _, ok := r.store.keys[event.IdempotencyKey]; ok {
+			return ErrDuplicateKey
+		}
+		r.store.keys[event.IdempotencyKey] = len(r.store.events)
+	}
+	r.store.events = append(r.store.events, *event)
+	r.store.sessions[session.SessionId] = copySession(session)
+	if outbox != nil {
+		r.store.outbox = append(r.store.outbox, *outbox)
+	}
+	return nil
+}
+
+func (r *answersheetRepository) checkVersion(session *entities.Session, expectedVersion int) error {
+	stored, ok := r.store.sessions[session.SessionId]
+	if expectedVersion == 0 {
+		if ok || r.attemptTaken(session) {
+			return entities.ErrVersionConflict
+		}
+	} else if !ok || stored.Version != expectedVersion {
+		return entities.ErrVersionConflict
+	}
+	return nil
+}
+
+func (r *answersheetRepository) attemptTaken(session *entities.Session) bool {
+	if session.Attempt == 0 {
+		return false
+	}
+	for _, item := range r.store.sessions {
+		if item.UserId == session.UserId && item.TestId == session.TestId && item.Attempt == session.Attempt {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *answersheetRepository) FindByIdempotencyKey(ctx context.Context, key string) (*entities.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	index, ok := r.store.keys[key]
+	if !ok {
+		return nil, entities.ErrNotFound
+	}
+	event := r.store.events[index]
+	return &event, nil
+}
+
+func (r *answersheetRepository) FindBySession(ctx context.Context, sessionId string) ([]entities.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	result := make([]entities.Event, 0)
+	for _, item := range r.store.events {
+		if item.Session == sessionId {
+			result = append(result, item)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return bytes.Compare(result[i].Id[:], result[j].Id[:]) < 0
+	})
+	return result, nil
+}
+
+func (r *answersheetRepository) SaveSession(ctx context.Context, session *entities.Session, expectedVersion int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkVersion(session, expectedVersion); err != nil {
+		return err
+	}
+	r.store.sessions[session.SessionId] = copySession(session)
+	return nil
+}
+
+func (r *answersheetRepository) FindSession(ctx context.Context, sessionId string) (*entities.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	session, ok := r.store.sessions[sessionId]
+	if !ok {
+		return nil, entities.ErrNotFound
+	}
+	return copySession(session), nil
+}
+
+func (r *answersheetRepository) FindLatestSession(ctx context.Context, userId int, testId int) (*entities.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var latest *entities.Session
+	for _, item := range r.store.sessions {
+		if item.UserId != userId || item.TestId != testId {
+			continue
+		}
+		if latest == nil || bytes.Compare(item.StartEventId[:], latest.StartEventId[:]) > 0 {
+			latest = item
+		}
+	}
+	if latest == nil {
+		return nil, entities.ErrNotFound
+	}
+	return copySession(latest), nil
+}
+
+func (r *answersheetRepository) FindExpiredSessions(ctx context.Context, now time.Time, limit int) ([]entities.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	result := make([]entities.Session, 0)
+	for _, item := range r.store.sessions {
+		if item.State == entities.SESSION_IN_PROGRESS && item.Deadline != nil && !item.Deadline.After(now) {
+			result = append(result, *copySession(item))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Deadline.Before(*result[j].Deadline)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *answersheetRepository) FindActiveSessions(ctx context.Context, testId int) ([]entities.Session, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	result := make([]entities.Session, 0)
+	for _, item := range r.store.sessions {
+		if item.TestId == testId && (item.State == entities.SESSION_IN_PROGRESS || item.State == entities.SESSION_PAUSED) {
+			result = append(result, *copySession(item))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].UserId < result[j].UserId
+	})
+	return result, nil
+}
+
+func (r *answersheetRepository) ListSessionIds(ctx context.Context) ([]string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	result := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, item := range r.store.events {
+		if item.Event != entities.START || item.Session == "" || seen[item.Session] {
+			continue
+		}
+		seen[item.Session] = true
+		result = append(result, item.Session)
+	}
+	return result, nil
+}
+
+// FindAnswerByUserIdAndTestId keeps the last stored answer of every question
+// before the END of the session, like the aggregation of the Mongo backend.
+func (r *answersheetRepository) FindAnswerByUserIdAndTestId(ctx context.Context, userId int, testId int, sessionId string) ([]entities.Event, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var end *primitive.ObjectID
+	for _, item := range r.store.events {
+		if item.Session == sessionId && item.Event == entities.END && (end == nil || bytes.Compare(item.Id[:], end[:]) < 0) {
+			id := item.Id
+			end = &id
+		}
+	}
+
+	last := make(map[int]string)
+	for _, item := range r.store.events {
+		if end != nil && bytes.Compare(item.Id[:], end[:]) >= 0 {
+			continue
+		}
+		if item.UserId != userId || item.TestId != testId || item.Event != entities.ANSWER || item.Session != sessionId {
+			continue
+		}
+		last[item.QuestionId] = item.Answer
+	}
+
+	data := make([]entities.Event, 0, len(last))
+	for questionId, answer := range last {
+		data = append(data, entities.Event{
+			UserId:     userId,
+			TestId:     testId,
+			Event:      entities.ANSWER,
+			QuestionId: questionId,
+			Answer:     answer,
+		})
+	}
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].QuestionId < data[j].QuestionId
+	})
+	return data, nil
+}
@@ -0,0 +1,276 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/entities"
+	"reflect"
+	"testing"
+)
+
+// storeEvent writes event with the next version of session, the way the
+// usecases do after applying it.
+func storeEvent(t *testing.T, r *answersheetRepository, session *entities.Session, event entities.Event) {
+	t.Helper()
+	event.Id = primitive.NewObjectID()
+	event.UserId = session.UserId
+	event.TestId = session.TestId
+	event.Session = session.SessionId
+	if event.Event == entities.START {
+		session.StartEventId = event.Id
+	}
+	expected := session.Version
+	session.Version++
+	if err := r.Create(context.Background(), &event, session, expected, nil); err != nil {
+		t.Fatalf("Create(%s) error = %v", event.Event, err)
+	}
+}
+
+func newTestSession(userId int, testId int, attempt int) *entities.Session {
+	return &entities.Session{
+		SessionId: primitive.NewObjectID().Hex(),
+		UserId:    userId,
+		TestId:    testId,
+		Attempt:   attempt,
+		State:     entities.SESSION_IN_PROGRESS,
+	}
+}
+
+func TestAnswersheetRepository_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		session  func(stored *entities.Session) *entities.Session
+		expected func(stored *entities.Session) int
+		key      string
+		wantErr  error
+	}{
+		{
+			name:     "current version",
+			session:  func(stored *entities.Session) *entities.Session { return stored },
+			expected: func(stored *entities.Session) int { return stored.Version },
+		},
+		{
+			name:     "stale version",
+			session:  func(stored *entities.Session) *entities.Session { return stored },
+			expected: func(stored *entities.Session) int { return stored.Version - 1 },
+			wantErr:  entities.ErrVersionConflict,
+		},
+		{
+			name:     "version ahead",
+			session:  func(stored *entities.Session) *entities.Session { return stored },
+			expected: func(stored *entities.Session) int { return stored.Version + 1 },
+			wantErr:  entities.ErrVersionConflict,
+		},
+		{
+			name:     "session created twice",
+			session:  func(stored *entities.Session) *entities.Session { return stored },
+			expected: func(stored *entities.Session) int { return 0 },
+			wantErr:  entities.ErrVersionConflict,
+		},
+		{
+			name:     "unknown session",
+			session:  func(stored *entities.Session) *entities.Session { return newTestSession(1, 2, 2) },
+			expected: func(stored *entities.Session) int { return 1 },
+			wantErr:  entities.ErrVersionConflict,
+		},
+		{
+			name:     "attempt already taken",
+			session:  func(stored *entities.Session) *entities.Session { return newTestSession(1, 2, 1) },
+			expected: func(stored *entities.Session) int { return 0 },
+			wantErr:  entities.ErrVersionConflict,
+		},
+		{
+			name:     "next attempt",
+			session:  func(stored *entities.Session) *entities.Session { return newTestSession(1, 2, 2) },
+			expected: func(stored *entities.Session) int { return 0 },
+		},
+		{
+			name:     "duplicate idempotency key",
+			session:  func(stored *entities.Session) *entities.Session { return stored },
+			expected: func(stored *entities.Session) int { return stored.Version },
+			key:      "start-1",
+			wantErr:  ErrDuplicateKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewAnswersheetRepository(NewStore())
+			ctx := context.Background()
+
+			stored := newTestSession(1, 2, 1)
+			storeEvent(t, r, stored, entities.Event{Event: entities.START, IdempotencyKey: "start-1"})
+			storeEvent(t, r, stored, entities.Event{Event: entities.ANSWER, QuestionId: 1, Answer: "A"})
+			before := copySession(stored)
+
+			session := copySession(tt.session(stored))
+			expected := tt.expected(stored)
+			session.Version = expected + 1
+			session.State = entities.SESSION_SUBMITTED
+			event := entities.Event{Id: primitive.NewObjectID(), UserId: 1, TestId: 2, Session: session.SessionId, Event: entities.END, IdempotencyKey: tt.key}
+			outbox := &entities.Outbox{Id: primitive.NewObjectID()}
+			err := r.Create(ctx, &event, session, expected, outbox)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// a rejected write leaves every collection as it was
+			wantEvents, wantOutbox := 3, 1
+			if tt.wantErr != nil {
+				wantEvents, wantOutbox = 2, 0
+			}
+			if got := len(r.store.events); got != wantEvents {
+				t.Errorf("events = %d, want %d", got, wantEvents)
+			}
+			if got := len(r.store.outbox); got != wantOutbox {
+				t.Errorf("outbox = %d, want %d", got, wantOutbox)
+			}
+			if tt.wantErr != nil {
+				if got := r.store.sessions[stored.SessionId]; !reflect.DeepEqual(got, before) {
+					t.Errorf("session = %+v, want %+v", got, before)
+				}
+			}
+		})
+	}
+}
+
+func TestAnswersheetRepository_SaveSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		session  func(stored *entities.Session) *entities.Session
+		expected func(stored *entities.Session) int
+		wantErr  error
+	}{
+		{
+			name:     "version read",
+			session:  func(stored *entities.Session) *entities.Session { return stored },
+			expected: func(stored *entities.Session) int { return stored.Version },
+		},
+		{
+			name:     "written since it was read",
+			session:  func(stored *entities.Session) *entities.Session { return stored },
+			expected: func(stored *entities.Session) int { return stored.Version - 1 },
+			wantErr:  entities.ErrVersionConflict,
+		},
+		{
+			name:     "stored since it was read",
+			session:  func(stored *entities.Session) *entities.Session { return stored },
+			expected: func(stored *entities.Session) int { return 0 },
+			wantErr:  entities.ErrVersionConflict,
+		},
+		{
+			name:     "missing session",
+			session:  func(stored *entities.Session) *entities.Session { return newTestSession(1, 2, 2) },
+			expected: func(stored *entities.Session) int { return 0 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewAnswersheetRepository(NewStore())
+			ctx := context.Background()
+
+			stored := newTestSession(1, 2, 1)
+			storeEvent(t, r, stored, entities.Event{Event: entities.START})
+			storeEvent(t, r, stored, entities.Event{Event: entities.ANSWER, QuestionId: 1, Answer: "A"})
+
+			session := *tt.session(stored)
+			session.State = entities.SESSION_SUBMITTED
+			err := r.SaveSession(ctx, &session, tt.expected(stored))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SaveSession() error = %v, want %v", err, tt.wantErr)
+			}
+
+			saved, err := r.FindSession(ctx, session.SessionId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantState := entities.SESSION_SUBMITTED
+			if tt.wantErr != nil {
+				wantState = stored.State
+			}
+			if saved.State != wantState {
+				t.Errorf("state = %s, want %s", saved.State, wantState)
+			}
+		})
+	}
+}
+
+func TestAnswersheetRepository_FindAnswerByUserIdAndTestId(t *testing.T) {
+	r := NewAnswersheetRepository(NewStore())
+
+	session := newTestSession(1, 2, 1)
+	storeEvent(t, r, session, entities.Event{Event: entities.START})
+	storeEvent(t, r, session, entities.Event{Event: entities.ANSWER, QuestionId: 1, Answer: "A"})
+	storeEvent(t, r, session, entities.Event{Event: entities.ANSWER, QuestionId: 2, Answer: "C"})
+	storeEvent(t, r, session, entities.Event{Event: entities.ANSWER, QuestionId: 1, Answer: "B"})
+	storeEvent(t, r, session, entities.Event{Event: entities.END})
+	// stored after the END, it must not count
+	storeEvent(t, r, session, entities.Event{Event: entities.ANSWER, QuestionId: 1, Answer: "D"})
+	storeEvent(t, r, session, entities.Event{Event: entities.ANSWER, QuestionId: 3, Answer: "A"})
+
+	// the answers of another attempt stay out of the result
+	retake := newTestSession(1, 2, 2)
+	storeEvent(t, r, retake, entities.Event{Event: entities.START})
+	storeEvent(t, r, retake, entities.Event{Event: entities.ANSWER, QuestionId: 2, Answer: "D"})
+
+	tests := []struct {
+		name    string
+		session string
+		want    map[int]string
+	}{
+		{name: "last answer before the end", session: session.SessionId, want: map[int]string{1: "B", 2: "C"}},
+		{name: "session still in progress", session: retake.SessionId, want: map[int]string{2: "D"}},
+		{name: "unknown session", session: "unknown", want: map[int]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := r.FindAnswerByUserIdAndTestId(context.Background(), 1, 2, tt.session)
+			if err != nil {
+				t.Fatalf("FindAnswerByUserIdAndTestId() error = %v", err)
+			}
+			got := make(map[int]string, len(events))
+			for _, event := range events {
+				got[event.QuestionId] = event.Answer
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAnswerByUserIdAndTestId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	latest, err := r.FindLatestSession(context.Background(), 1, 2)
+	if err != nil || latest.SessionId != retake.SessionId {
+		t.Fatalf("FindLatestSession() = %v, %v, want the retake", latest, err)
+	}
+}
+
+func TestAnswersheetRepository_SessionsAreCopied(t *testing.T) {
+	r := NewAnswersheetRepository(NewStore())
+	ctx := context.Background()
+
+	session := newTestSession(1, 2, 1)
+	session.Answers = map[int]entities.SessionAnswer{1: {QuestionId: 1, Answer: "A"}}
+	session.Flagged = []int{1}
+	storeEvent(t, r, session, entities.Event{Event: entities.START})
+
+	// neither the written nor a read session share state with the store
+	session.Answers[1] = entities.SessionAnswer{QuestionId: 1, Answer: "B"}
+	session.Flagged[0] = 2
+	read, err := r.FindLatestSession(ctx, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read.Answers[2] = entities.SessionAnswer{QuestionId: 2, Answer: "C"}
+	read.Flagged[0] = 3
+
+	stored, err := r.FindLatestSession(ctx, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int]entities.SessionAnswer{1: {QuestionId: 1, Answer: "A"}}; !reflect.DeepEqual(stored.Answers, want) {
+		t.Errorf("Answers = %v, want %v", stored.Answers, want)
+	}
+	if want := []int{1}; !reflect.DeepEqual(stored.Flagged, want) {
+		t.Errorf("Flagged = %v, want %v", stored.Flagged, want)
+	}
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+type outboxRepository struct {
+	store *store
+}
+
+func NewOutboxRepository(store *store) *outboxRepository {
+	return &outboxRepository{store: store}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, outbox *entities.Outbox) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.outbox = append(r.store.outbox, *outbox)
+	return nil
+}
+
+func (r *outboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]entities.Outbox, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	lockedUntil := now.Add(lease)
+	result := make([]entities.Outbox, 0, limit)
+	for index := range r.store.outbox {
+		if len(result) >= limit {
+			break
+		}
+		item := &r.store.outbox[index]
+		if item.Status != entities.OUTBOX_PENDING || (item.LockedUntil != nil && !item.LockedUntil.Before(now)) {
+			continue
+		}
+		item.LockedUntil = &lockedUntil
+		item.Attempts++
+		result = append(result, *item)
+	}
+	return result, nil
+}
+
+func (r *outboxRepository) find(id primitive.ObjectID) *entities.Outbox {
+	for index := range r.store.outbox {
+		if r.store.outbox[index].Id == id {
+			return &r.store.outbox[index]
+		}
+	}
+	return nil
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, id primitive.ObjectID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if item := r.find(id); item != nil {
+		now := time.Now()
+		item.Status = entities.OUTBOX_SENT
+		item.SentAt = &now
+		item.LockedUntil = nil
+		item.LastError = ""
+	}
+	return nil
+}
+
+func (r *outboxRepository) Release(ctx context.Context, id primitive.ObjectID, errMessage string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if item := r.find(id); item != nil {
+		item.LastError = errMessage
+		item.LockedUntil = nil
+	}
+	return nil
+}
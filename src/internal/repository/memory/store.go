@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"errors"
+	"picket-answersheet-service/src/internal/entities"
+	"sync"
+)
+
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// store holds every collection of the memory backend behind one lock so that
+// Create can write the event, the session and the outbox row atomically, like
+// the transaction of the other backends.
+type store struct {
+	mu       sync.RWMutex
+	events   []entities.Event
+	keys     map[string]int
+	sessions map[string]*entities.Session
+	tests    map[testKey]entities.Test
+	outbox   []entities.Outbox
+
+	accommodations map[accommodationKey]entities.Accommodation
+}
+
+type testKey struct {
+	testId  int
+	version int
+}
+
+type accommodationKey struct {
+	testId int
+	userId int
+}
+
+func NewStore() *store {
+	return &store{
+		keys:     make(map[string]int),
+		sessions: make(map[string]*entities.Session),
+		tests:    make(map[testKey]entities.Test),
+
+		accommodations: make(map[accommodationKey]entities.Accommodation),
+	}
+}
+
+func copySession(session *entities.Session) *entities.Session {
+	result := *session
+	if session.Answers != nil {
+		result.Answers = make(map[int]entities.SessionAnswer, len(session.Answers))
+		for key, value := range session.Answers {
+			result.Answers[key] = value
+		}
+	}
+	result.Flagged = append([]int(nil), session.Flagged...)
+	return &result
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"picket-answersheet-service/src/internal/entities"
+)
+
+type testRepository struct {
+	store *store
+}
+
+func NewTestRepository(store *store) *testRepository {
+	return &testRepository{store: store}
+}
+
+func (r *testRepository) Create(ctx context.Context, test *entities.Test) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := testKey{testId: test.TestId, version: test.Version}
+	if _, ok := r.store.tests[key]; ok {
+		return ErrDuplicateKey
+	}
+	r.store.tests[key] = *test
+	return nil
+}
+
+// FindByTestId returns the newest version of the test.
+func (r *testRepository) FindByTestId(ctx context.Context, testId int) (*entities.Test, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var result *entities.Test
+	for key, test := range r.store.tests {
+		if key.testId == testId && (result == nil || test.Version > result.Version) {
+			test := test
+			result = &test
+		}
+	}
+	if result == nil {
+		return nil, entities.ErrNotFound
+	}
+	return result, nil
+}
+
+func (r *testRepository) FindByTestIdAndVersion(ctx context.Context, testId int, version int) (*entities.Test, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	test, ok := r.store.tests[testKey{testId: testId, version: version}]
+	if !ok {
+		return nil, entities.ErrNotFound
+	}
+	return &test, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+type outboxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(config config.IConfig) *outboxRepository {
+	database := config.GetMongo().Database(config.GetMongoDatabase())
+	return &outboxRepository{collection: database.Collection(config.GetOutboxCollection())}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, outbox *entities.Outbox) error {
+	_, err := r.collection.InsertOne(ctx, outbox)
+	return err
+}
+
+// ClaimPending leases up to limit pending rows so that concurrent relays never
+// publish the same row twice while the lease is held.
+func (r *outboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]entities.Outbox, error) {
+	result := make([]entities.Outbox, 0, limit)
+
+	for len(result) < limit {
+		now := time.Now()
+		lockedUntil := now.Add(lease)
+		filter := bson.M{
+			"status": entities.OUTBOX_PENDING,
+			"$or": bson.A{
+				bson.M{"locked_until": bson.M{"$exists": false}},
+				bson.M{"locked_until": bson.M{"$lt": now}},
+			},
+		}
+		update := bson.M{
+			"$set": bson.M{"locked_until": lockedUntil},
+			"$inc": bson.M{"attempts": 1},
+		}
+		opts := options.FindOneAndUpdate().SetSort(bson.D{{"_id", 1}}).SetReturnDocument(options.After)
+
+		var outbox entities.Outbox
+		err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&outbox)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, outbox)
+	}
+
+	return result, nil
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, id primitive.ObjectID) error {
+	now := time.Now()
+	_, err := r.collection.UpdateByID(ctx, id, bson.M{
+		"$set":   bson.M{"status": entities.OUTBOX_SENT, "sent_at": now},
+		"$unset": bson.M{"locked_until": "", "last_error": ""},
+	})
+	return err
+}
+
+func (r *outboxRepository) Release(ctx context.Context, id primitive.ObjectID, errMessage string) error {
+	_, err := r.collection.UpdateByID(ctx, id, bson.M{
+		"$set":   bson.M{"last_error": errMessage},
+		"$unset": bson.M{"locked_until": ""},
+	})
+	return err
+}
@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/entities"
+)
+
+type accommodationRepository struct {
+	db    *gorm.DB
+	table string
+}
+
+func NewAccommodationRepository(config config.IConfig) *accommodationRepository {
+	return &accommodationRepository{db: config.GetPostgres(), table: config.GetAccommodationsCollection()}
+}
+
+func (r *accommodationRepository) Save(ctx context.Context, accommodation *entities.Accommodation) error {
+	row := newAccommodationRow(accommodation)
+	return r.db.WithContext(ctx).Table(r.table).Clauses(clause.OnConflict{UpdateAll: true}).Create(&row).Error
+}
+
+func (r *accommodationRepository) FindByTestIdAndUserId(ctx context.Context, testId int, userId int) (*entities.Accommodation, error) {
+	var row accommodationRow
+	if err := r.db.WithContext(ctx).Table(r.table).Where("test_id = ? AND user_id = ?", testId, userId).Take(&row).Error; err != nil {
+		return nil, mapError(err)
+	}
+	return row.entity(), nil
+}
@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+type answersheetRepository struct {
+	db       *gorm.DB
+	events   string
+	sessions string
+	outbox   string
+}
+
+func NewAnswersheetRepository(config config.IConfig) *answersheetRepository {
+	return &answersheetRepository{
+		db:       config.GetPostgres(),
+		events:   config.GetEventsCollection(),
+		sessions: config.GetSessionsCollection(),
+		outbox:   config.GetOutboxCollection(),
+	}
+}
+
+func mapError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.ErrNotFound
+	}
+	return err
+}
+
+// isUniqueViolation reports a unique_violation, SQLSTATE 23505.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// Create stores the event, the session and the outbox row in one transaction.
+// The stored session must still be at expectedVersion, 0 meaning it must not
+// exist yet.
+func (r *answersheetRepository) Create(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row, err := newEventRow(event)
+		if err != nil {
+			return err
+		}
+		if err := tx.Table(r.events).Create(&row).Error; err != nil {
+			return err
+		}
+		if err := r.swapSession(tx, session, expectedVersion); err != nil {
+			return err
+		}
+		if outbox == nil {
+			return nil
+		}
+		outboxRow := newOutboxRow(outbox)
+		return tx.Table(r.outbox).Create(&outboxRow).Error
+	})
+}
+
+func (r *answersheetRepository) FindByIdempotencyKey(ctx context.Context, key string) (*entities.Event, error) {
+	var row eventRow
+	err := r.db.WithContext(ctx).Table(r.events).Where("idempotency_key = ?", key).Take(&row).Error
+	if err != nil {
+		return nil, mapError(err)
+	}
+	event, err := row.entity()
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *answersheetRepository) FindBySession(ctx context.Context, sessionId string) ([]entities.Event, error) {
+	var rows []eventRow
+	err := r.db.WithContext(ctx).Table(r.events).Where("session = ?", sessionId).Order("id").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]entities.Event, len(rows))
+	for index, row := range rows {
+		if result[index], err = row.entity(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (r *answersheetRepository) swapSession(tx *gorm.DB, session *entities.Session, expectedVersion int) error {
+	row, err := newSessionRow(session)
+	if err != nil {
+		return err
+	}
+	if expectedVersion == 0 {
+		err := tx.Table(r.sessions).Create(&row).Error
+		if isUniqueViolation(err) {
+			return entities.ErrVersionConflict
+		}
+		return err
+	}
+
+	result := tx.Table(r.sessions).
+		Where("id = ? AND version = ?", row.Id, expectedVersion).
+		Select("*").
+		Updates(&row)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrVersionConflict
+	}
+	return nil
+}
+
+// SaveSession writes a session without an event, under the same version check
+// as Create.
+func (r *answersheetRepository) SaveSession(ctx context.Context, session *entities.Session, expectedVersion int) error {
+	return r.swapSession(r.db.WithContext(ctx), session, expectedVersion)
+}
+
+func (r *answersheetRepository) FindSession(ctx context.Context, sessionId string) (*entities.Session, error) {
+	var row sessionRow
+	err := r.db.WithContext(ctx).Table(r.sessions).Where("id = ?", sessionId).Take(&row).Error
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return row.entity()
+}
+
+func (r *answersheetRepository) FindLatestSession(ctx context.Context, userId int, testId int) (*entities.Session, error) {
+	var row sessionRow
+	err := r.db.WithContext(ctx).Table(r.sessions).
+		Where("user_id = ? AND test_id = ?", userId, testId).
+		Order("start_event_id DESC").
+		Take(&row).Error
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return row.entity()
+}
+
+func (r *answersheetRepository) FindExpiredSessions(ctx context.Context, now time.Time, limit int) ([]entities.Session, error) {
+	var rows []sessionRow
+	err := r.db.WithContext(ctx).Table(r.sessions).
+		Where("state = ? AND deadline <= ?", entities.SESSION_IN_PROGRESS, now).
+		Order("deadline").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]entities.Session, 0, len(rows))
+	for _, row := range rows {
+		session, err := row.entity()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *session)
+	}
+	return result, nil
+}
+
+func (r *answersheetRepository) FindActiveSessions(ctx context.Context, testId int) ([]entities.Session, error) {
+	var rows []sessionRow
+	err := r.db.WithContext(ctx).Table(r.sessions).
+		Where("test_id = ? AND state IN ?", testId, []string{entities.SESSION_IN_PROGRESS, entities.SESSION_PAUSED}).
+		Order("user_id").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]entities.Session, 0, len(rows))
+	for _, row := range rows {
+		session, err := row.entity()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *session)
+	}
+	return result, nil
+}
+
+func (r *answersheetRepository) ListSessionIds(ctx context.Context) ([]string, error) {
+	var result []string
+	err := r.db.WithContext(ctx).Table(r.events).
+		Where("event = ? AND session <> ''", entities.START).
+		Distinct().
+		Pluck("session", &result).Error
+	return result, err
+}
+
+// FindAnswerByUserIdAndTestId keeps the last answer of every question stored
+// before the END of the session, the same result as the Mongo aggregation.
+func (r *answersheetRepository) FindAnswerByUserIdAndTestId(ctx context.Context, userId int, testId int, sessionId string) ([]entities.Event, error) {
+	var rows []eventRow
+	err := r.db.WithContext(ctx).Table(r.events).
+		Select("DISTINCT ON (question_id) *").
+		Where("user_id = ? AND test_id = ? AND event = ? AND session = ?", userId, testId, entities.ANSWER, sessionId).
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]s e WHERE e.session = %[1]s.session AND e.event = ? AND e.id < %[1]s.id)", r.events), entities.END).
+		Order("question_id, id DESC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]entities.Event, len(rows))
+	for index, row := range rows {
+		data[index] = entities.Event{
+			UserId:     userId,
+			TestId:     testId,
+			Event:      entities.ANSWER,
+			QuestionId: row.QuestionId,
+			Answer:     row.Answer,
+		}
+	}
+	return data, nil
+}
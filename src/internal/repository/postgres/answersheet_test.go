@@ -0,0 +1,319 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/entities"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The suite runs against the database of POSTGRES_TEST_URL, each test in a
+// schema of its own that is dropped afterwards:
+//
+//	POSTGRES_TEST_URL=postgres://localhost/answersheet go test -tags integration ./src/internal/repository/postgres
+func newTestRepository(t *testing.T) *answersheetRepository {
+	t.Helper()
+	url := os.Getenv("POSTGRES_TEST_URL")
+	if url == "" {
+		t.Skip("POSTGRES_TEST_URL is not set")
+	}
+	silent := &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+
+	admin, err := gorm.Open(driver.Open(url), silent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := fmt.Sprintf("answersheet_test_%d", time.Now().UnixNano())
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := admin.Exec("DROP SCHEMA " + schema + " CASCADE").Error; err != nil {
+			t.Error(err)
+		}
+	})
+
+	separator := " "
+	if strings.Contains(url, "://") {
+		separator = "?"
+		if strings.Contains(url, "?") {
+			separator = "&"
+		}
+	}
+	db, err := gorm.Open(driver.Open(url+separator+"search_path="+schema), silent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iConfig := testConfig{db: db}
+	if _, err := NewMigrator(iConfig).Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return NewAnswersheetRepository(iConfig)
+}
+
+// testConfig answers the settings the repositories read.
+type testConfig struct {
+	config.IConfig
+	db *gorm.DB
+}
+
+func (c testConfig) GetPostgres() *gorm.DB               { return c.db }
+func (c testConfig) GetEventsCollection() string         { return "events" }
+func (c testConfig) GetTestsCollection() string          { return "tests" }
+func (c testConfig) GetSessionsCollection() string       { return "sessions" }
+func (c testConfig) GetOutboxCollection() string         { return "outbox" }
+func (c testConfig) GetAccommodationsCollection() string { return "accommodations" }
+
+// store writes event with the next version of session, the way the usecases
+// do after applying it.
+func store(t *testing.T, r *answersheetRepository, session *entities.Session, event entities.Event) {
+	t.Helper()
+	event.Id = primitive.NewObjectID()
+	event.UserId = session.UserId
+	event.TestId = session.TestId
+	event.Session = session.SessionId
+	expected := session.Version
+	session.Version++
+	if err := r.Create(context.Background(), &event, session, expected, nil); err != nil {
+		t.Fatalf("Create(%s) error = %v", event.Event, err)
+	}
+}
+
+func newTestSession(userId int, testId int, attempt int) *entities.Session {
+	return &entities.Session{
+		SessionId: primitive.NewObjectID().Hex(),
+		UserId:    userId,
+		TestId:    testId,
+		Attempt:   attempt,
+		State:     entities.SESSION_IN_PROGRESS,
+	}
+}
+
+func TestAnswersheetRepository_FindAnswerByUserIdAndTestId(t *testing.T) {
+	r := newTestRepository(t)
+
+	session := newTestSession(1, 2, 1)
+	store(t, r, session, entities.Event{Event: entities.START})
+	store(t, r, session, entities.Event{Event: entities.ANSWER, QuestionId: 1, Answer: "A"})
+	store(t, r, session, entities.Event{Event: entities.ANSWER, QuestionId: 2, Answer: "C"})
+	store(t, r, session, entities.Event{Event: entities.ANSWER, QuestionId: 1, Answer: "B"})
+	store(t, r, session, entities.Event{Event: entities.END})
+	// stored after the END, it must not count
+	store(t, r, session, entities.Event{Event: entities.ANSWER, QuestionId: 1, Answer: "D"})
+	store(t, r, session, entities.Event{Event: entities.ANSWER, QuestionId: 3, Answer: "A"})
+
+	// the answers of another attempt stay out of the result
+	retake := newTestSession(1, 2, 2)
+	store(t, r, retake, entities.Event{Event: entities.START})
+	store(t, r, retake, entities.Event{Event: entities.ANSWER, QuestionId: 2, Answer: "D"})
+
+	tests := []struct {
+		name    string
+		session string
+		want    map[int]string
+	}{
+		{name: "last answer before the end", session: session.SessionId, want: map[int]string{1: "B", 2: "C"}},
+		{name: "session still in progress", session: retake.SessionId, want: map[int]string{2: "D"}},
+		{name: "unknown session", session: "unknown", want: map[int]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := r.FindAnswerByUserIdAndTestId(context.Background(), 1, 2, tt.session)
+			if err != nil {
+				t.Fatalf("FindAnswerByUserIdAndTestId() error = %v", err)
+			}
+			got := make(map[int]string, len(events))
+			for _, event := range events {
+				got[event.QuestionId] = event.Answer
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAnswerByUserIdAndTestId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnswersheetRepository_FindLatestSession(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+
+	first := newTestSession(1, 2, 1)
+	store(t, r, first, entities.Event{Event: entities.START})
+	store(t, r, first, entities.Event{Event: entities.END})
+	retake := newTestSession(1, 2, 2)
+	store(t, r, retake, entities.Event{Event: entities.START})
+
+	stored, err := r.FindLatestSession(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("FindLatestSession() error = %v", err)
+	}
+	if stored.SessionId != retake.SessionId || stored.Version != retake.Version {
+		t.Errorf("FindLatestSession() = %s version %d, want %s version %d", stored.SessionId, stored.Version, retake.SessionId, retake.Version)
+	}
+	if _, err := r.FindLatestSession(ctx, 1, 3); !errors.Is(err, entities.ErrNotFound) {
+		t.Errorf("FindLatestSession() error = %v, want %v", err, entities.ErrNotFound)
+	}
+}
+
+func TestAnswersheetRepository_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected func(session *entities.Session) int
+		wantErr  error
+	}{
+		{name: "current version", expected: func(session *entities.Session) int { return session.Version }},
+		{name: "stale version", expected: func(session *entities.Session) int { return session.Version - 1 }, wantErr: entities.ErrVersionConflict},
+		{name: "session created twice", expected: func(session *entities.Session) int { return 0 }, wantErr: entities.ErrVersionConflict},
+		{name: "version ahead", expected: func(session *entities.Session) int { return session.Version + 1 }, wantErr: entities.ErrVersionConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepository(t)
+			ctx := context.Background()
+
+			session := newTestSession(1, 2, 1)
+			store(t, r, session, entities.Event{Event: entities.START})
+			store(t, r, session, entities.Event{Event: entities.ANSWER, QuestionId: 1, Answer: "A"})
+
+			event := entities.Event{Id: primitive.NewObjectID(), UserId: 1, TestId: 2, Session: session.SessionId, Event: entities.ANSWER, QuestionId: 2, Answer: "B"}
+			next := *session
+			next.Version++
+			next.State = entities.SESSION_SUBMITTED
+			err := r.Create(ctx, &event, &next, tt.expected(session), nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// a conflict rolls the event back together with the session
+			events, err := r.FindBySession(ctx, session.SessionId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stored, err := r.FindLatestSession(ctx, 1, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantEvents, wantVersion, wantState := 3, next.Version, entities.SESSION_SUBMITTED
+			if tt.wantErr != nil {
+				wantEvents, wantVersion, wantState = 2, session.Version, session.State
+			}
+			if len(events) != wantEvents {
+				t.Errorf("events = %d, want %d", len(events), wantEvents)
+			}
+			if stored.Version != wantVersion || stored.State != wantState {
+				t.Errorf("session version %d state %s, want %d %s", stored.Version, stored.State, wantVersion, wantState)
+			}
+		})
+	}
+}
+
+func TestAnswersheetRepository_SaveSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		session  func(stored *entities.Session) *entities.Session
+		expected func(stored *entities.Session) int
+		wantErr  error
+	}{
+		{
+			name:     "version read",
+			session:  func(stored *entities.Session) *entities.Session { return stored },
+			expected: func(stored *entities.Session) int { return stored.Version },
+		},
+		{
+			name:     "written since it was read",
+			session:  func(stored *entities.Session) *entities.Session { return stored },
+			expected: func(stored *entities.Session) int { return stored.Version - 1 },
+			wantErr:  entities.ErrVersionConflict,
+		},
+		{
+			name:     "stored since it was read",
+			session:  func(stored *entities.Session) *entities.Session { return stored },
+			expected: func(stored *entities.Session) int { return 0 },
+			wantErr:  entities.ErrVersionConflict,
+		},
+		{
+			name:     "missing session",
+			session:  func(stored *entities.Session) *entities.Session { return newTestSession(1, 2, 2) },
+			expected: func(stored *entities.Session) int { return 0 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepository(t)
+			ctx := context.Background()
+
+			stored := newTestSession(1, 2, 1)
+			store(t, r, stored, entities.Event{Event: entities.START})
+			store(t, r, stored, entities.Event{Event: entities.ANSWER, QuestionId: 1, Answer: "A"})
+
+			session := *tt.session(stored)
+			session.State = entities.SESSION_SUBMITTED
+			err := r.SaveSession(ctx, &session, tt.expected(stored))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SaveSession() error = %v, want %v", err, tt.wantErr)
+			}
+
+			saved, err := r.FindSession(ctx, session.SessionId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantState := entities.SESSION_SUBMITTED
+			if tt.wantErr != nil {
+				wantState = stored.State
+			}
+			if saved.State != wantState {
+				t.Errorf("state = %s, want %s", saved.State, wantState)
+			}
+		})
+	}
+}
+
+func TestAnswersheetRepository_CreateConcurrent(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+
+	session := newTestSession(1, 2, 1)
+	store(t, r, session, entities.Event{Event: entities.START})
+
+	// writers that read the same version race, exactly one of them wins
+	const writers = 8
+	errs := make(chan error, writers)
+	for index := 0; index < writers; index++ {
+		go func(index int) {
+			event := entities.Event{Id: primitive.NewObjectID(), UserId: 1, TestId: 2, Session: session.SessionId, Event: entities.ANSWER, QuestionId: index + 1, Answer: "A"}
+			next := *session
+			next.Version++
+			errs <- r.Create(ctx, &event, &next, session.Version, nil)
+		}(index)
+	}
+	var results []string
+	for index := 0; index < writers; index++ {
+		err := <-errs
+		switch {
+		case err == nil:
+			results = append(results, "ok")
+		case errors.Is(err, entities.ErrVersionConflict):
+			results = append(results, "conflict")
+		default:
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	sort.Strings(results)
+	if results[0] != "conflict" || results[writers-2] != "conflict" || results[writers-1] != "ok" {
+		t.Errorf("results = %v, want one ok", results)
+	}
+}
@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"picket-answersheet-service/src/config"
+	"time"
+)
+
+type migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type schemaMigration struct {
+	Version   int       `gorm:"column:version;primaryKey"`
+	Name      string    `gorm:"column:name"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// migrations mirror the Mongo ones, table names follow the configured
+// collection names. Append new ones with the next version.
+func migrations(config config.IConfig) []migration {
+	events := config.GetEventsCollection()
+	tests := config.GetTestsCollection()
+	sessions := config.GetSessionsCollection()
+	outbox := config.GetOutboxCollection()
+	accommodations := config.GetAccommodationsCollection()
+
+	return []migration{
+		{1, "create_" + events, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id CHAR(24) PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	test_id INTEGER NOT NULL,
+	event VARCHAR(16) NOT NULL,
+	session VARCHAR(64) NOT NULL DEFAULT '',
+	question_id INTEGER NOT NULL DEFAULT 0,
+	answer TEXT NOT NULL DEFAULT '',
+	previous_answer TEXT NOT NULL DEFAULT '',
+	idempotency_key VARCHAR(255) UNIQUE,
+	created_at TIMESTAMPTZ,
+	updated_at TIMESTAMPTZ
+)`, events), fmt.Sprintf(`DROP TABLE IF EXISTS %s`, events)},
+		{2, events + "_user_test_event", fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_user_test_event ON %[1]s (user_id, test_id, event, id)`, events),
+			fmt.Sprintf(`DROP INDEX IF EXISTS %s_user_test_event`, events)},
+		{3, events + "_session", fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_session ON %[1]s (session, question_id, id)`, events),
+			fmt.Sprintf(`DROP INDEX IF EXISTS %s_session`, events)},
+		{4, "create_" + tests, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	test_id INTEGER PRIMARY KEY,
+	data JSONB NOT NULL
+)`, tests), fmt.Sprintf(`DROP TABLE IF EXISTS %s`, tests)},
+		{5, "create_" + sessions, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id VARCHAR(64) PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	test_id INTEGER NOT NULL,
+	state VARCHAR(16) NOT NULL,
+	answers JSONB,
+	started_at TIMESTAMPTZ,
+	ended_at TIMESTAMPTZ,
+	deadline TIMESTAMPTZ,
+	start_event_id CHAR(24) NOT NULL DEFAULT '',
+	last_event_id CHAR(24) NOT NULL DEFAULT '',
+	version INTEGER NOT NULL DEFAULT 0,
+	updated_at TIMESTAMPTZ
+)`, sessions), fmt.Sprintf(`DROP TABLE IF EXISTS %s`, sessions)},
+		{6, sessions + "_user_test", fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_user_test ON %[1]s (user_id, test_id, start_event_id DESC)`, sessions),
+			fmt.Sprintf(`DROP INDEX IF EXISTS %s_user_test`, sessions)},
+		{7, "create_" + outbox, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id CHAR(24) PRIMARY KEY,
+	topic VARCHAR(255) NOT NULL,
+	payload BYTEA,
+	status VARCHAR(16) NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT '',
+	locked_until TIMESTAMPTZ,
+	created_at TIMESTAMPTZ,
+	sent_at TIMESTAMPTZ
+)`, outbox), fmt.Sprintf(`DROP TABLE IF EXISTS %s`, outbox)},
+		{8, outbox + "_status", fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_status ON %[1]s (status, id)`, outbox),
+			fmt.Sprintf(`DROP INDEX IF EXISTS %s_status`, outbox)},
+		{9, sessions + "_attempt", fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS attempt INTEGER NOT NULL DEFAULT 0`, sessions),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS attempt`, sessions)},
+		{10, sessions + "_user_test_attempt", fmt.Sprintf(`CREATE UNIQUE INDEX IF NOT EXISTS %[1]s_user_test_attempt ON %[1]s (user_id, test_id, attempt) WHERE attempt > 0`, sessions),
+			fmt.Sprintf(`DROP INDEX IF EXISTS %s_user_test_attempt`, sessions)},
+		{11, events + "_client_time", fmt.Sprintf(`ALTER TABLE %s
+	ADD COLUMN IF NOT EXISTS client_created_at TIMESTAMPTZ,
+	ADD COLUMN IF NOT EXISTS clock_skew BIGINT NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS skew_flagged BOOLEAN NOT NULL DEFAULT FALSE`, events),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS client_created_at, DROP COLUMN IF EXISTS clock_skew, DROP COLUMN IF EXISTS skew_flagged`, events)},
+		{12, events + "_reason", fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS reason VARCHAR(64) NOT NULL DEFAULT ''`, events),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS reason`, events)},
+		{13, sessions + "_state_deadline", fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_state_deadline ON %[1]s (state, deadline)`, sessions),
+			fmt.Sprintf(`DROP INDEX IF EXISTS %s_state_deadline`, sessions)},
+		{14, events + "_actor", fmt.Sprintf(`ALTER TABLE %s
+	ADD COLUMN IF NOT EXISTS actor_id INTEGER NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS extension BIGINT NOT NULL DEFAULT 0,
+	ALTER COLUMN reason TYPE TEXT`, events),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS actor_id, DROP COLUMN IF EXISTS extension, ALTER COLUMN reason TYPE VARCHAR(64) USING left(reason, 64)`, events)},
+		{15, sessions + "_extension", fmt.Sprintf(`ALTER TABLE %s
+	ADD COLUMN IF NOT EXISTS extension BIGINT NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS skew_flags INTEGER NOT NULL DEFAULT 0`, sessions),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS extension, DROP COLUMN IF EXISTS skew_flags`, sessions)},
+		{16, sessions + "_test_state", fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %[1]s_test_state ON %[1]s (test_id, state, user_id)`, sessions),
+			fmt.Sprintf(`DROP INDEX IF EXISTS %s_test_state`, sessions)},
+		{17, "create_" + accommodations, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	test_id INTEGER NOT NULL,
+	user_id INTEGER NOT NULL,
+	extra_minutes INTEGER NOT NULL DEFAULT 0,
+	multiplier DOUBLE PRECISION NOT NULL DEFAULT 0,
+	updated_by INTEGER NOT NULL DEFAULT 0,
+	updated_at TIMESTAMPTZ,
+	PRIMARY KEY (test_id, user_id)
+)`, accommodations), fmt.Sprintf(`DROP TABLE IF EXISTS %s`, accommodations)},
+		{18, sessions + "_paused", fmt.Sprintf(`ALTER TABLE %s
+	ADD COLUMN IF NOT EXISTS paused_at TIMESTAMPTZ,
+	ADD COLUMN IF NOT EXISTS paused BIGINT NOT NULL DEFAULT 0`, sessions),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS paused_at, DROP COLUMN IF EXISTS paused`, sessions)},
+		{19, events + "_test_version", fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS test_version INTEGER NOT NULL DEFAULT 0`, events),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS test_version`, events)},
+		{20, sessions + "_test_version", fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS test_version INTEGER NOT NULL DEFAULT 0`, sessions),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS test_version`, sessions)},
+		{21, sessions + "_shuffle", fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS shuffle JSONB`, sessions),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS shuffle`, sessions)},
+		{22, sessions + "_flagged", fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS flagged JSONB`, sessions),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS flagged`, sessions)},
+		{23, events + "_shuffle", fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS shuffle JSONB`, events),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS shuffle`, events)},
+		// every synced version of a test is kept, sessions are scored against
+		// the version they started with
+		{24, tests + "_version", fmt.Sprintf(`ALTER TABLE %[1]s
+	ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 0,
+	DROP CONSTRAINT IF EXISTS %[1]s_pkey,
+	ADD PRIMARY KEY (test_id, version);
+UPDATE %[1]s SET version = COALESCE((data->>'version')::INTEGER, 0)`, tests),
+			fmt.Sprintf(`DELETE FROM %[1]s t WHERE EXISTS (SELECT 1 FROM %[1]s n WHERE n.test_id = t.test_id AND n.version > t.version);
+ALTER TABLE %[1]s DROP CONSTRAINT IF EXISTS %[1]s_pkey, ADD PRIMARY KEY (test_id), DROP COLUMN IF EXISTS version`, tests)},
+	}
+}
+
+type migrator struct {
+	db         *gorm.DB
+	migrations []migration
+}
+
+func NewMigrator(config config.IConfig) *migrator {
+	return &migrator{db: config.GetPostgres(), migrations: migrations(config)}
+}
+
+func (m *migrator) applied(ctx context.Context) (map[int]bool, error) {
+	if err := m.db.WithContext(ctx).Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+	version INTEGER PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL
+)`).Error; err != nil {
+		return nil, err
+	}
+	var records []schemaMigration
+	if err := m.db.WithContext(ctx).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[int]bool, len(records))
+	for _, item := range records {
+		result[item.Version] = true
+	}
+	return result, nil
+}
+
+// Up applies every pending migration, each one in its own transaction together
+// with its schema_migrations row.
+func (m *migrator) Up(ctx context.Context) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, item := range m.migrations {
+		if applied[item.Version] {
+			continue
+		}
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(item.Up).Error; err != nil {
+				return err
+			}
+			record := schemaMigration{Version: item.Version, Name: item.Name, AppliedAt: time.Now()}
+			return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&record).Error
+		})
+		if err != nil {
+			return count, err
+		}
+		log.Info().Int("version", item.Version).Str("name", item.Name).Msg("migrate up")
+		count++
+	}
+	return count, nil
+}
+
+func (m *migrator) Down(ctx context.Context) (bool, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for index := len(m.migrations) - 1; index >= 0; index-- {
+		item := m.migrations[index]
+		if !applied[item.Version] {
+			continue
+		}
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(item.Down).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, item.Version).Error
+		})
+		if err != nil {
+			return false, err
+		}
+		log.Info().Int("version", item.Version).Str("name", item.Name).Msg("migrate down")
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,328 @@
+package postgres
+
+import (
+	"encoding/json"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+// Rows use the hex form of the ObjectIDs generated by the usecases as primary
+// key, it sorts like the ObjectID itself so "order by id" matches the insertion
+// order the Mongo backend relies on.
+
+type eventRow struct {
+	Id             string     `gorm:"column:id;primaryKey"`
+	UserId         int        `gorm:"column:user_id"`
+	TestId         int        `gorm:"column:test_id"`
+	Event          string     `gorm:"column:event"`
+	Session        string     `gorm:"column:session"`
+	QuestionId     int        `gorm:"column:question_id"`
+	Answer         string     `gorm:"column:answer"`
+	PreviousAnswer string     `gorm:"column:previous_answer"`
+	Reason         string     `gorm:"column:reason"`
+	IdempotencyKey *string    `gorm:"column:idempotency_key"`
+	CreatedAt      *time.Time `gorm:"column:created_at"`
+	UpdatedAt      *time.Time `gorm:"column:updated_at"`
+
+	ClientCreatedAt *time.Time    `gorm:"column:client_created_at"`
+	ClockSkew       time.Duration `gorm:"column:clock_skew"`
+	SkewFlagged     bool          `gorm:"column:skew_flagged"`
+
+	ActorId   int           `gorm:"column:actor_id"`
+	Extension time.Duration `gorm:"column:extension"`
+
+	TestVersion int    `gorm:"column:test_version"`
+	Shuffle     []byte `gorm:"column:shuffle"`
+}
+
+func newEventRow(event *entities.Event) (eventRow, error) {
+	row := eventRow{
+		Id:             event.Id.Hex(),
+		UserId:         event.UserId,
+		TestId:         event.TestId,
+		Event:          event.Event,
+		Session:        event.Session,
+		QuestionId:     event.QuestionId,
+		Answer:         event.Answer,
+		PreviousAnswer: event.PreviousAnswer,
+		Reason:         event.Reason,
+		CreatedAt:      event.CreatedAt,
+		UpdatedAt:      event.UpdatedAt,
+
+		ClientCreatedAt: event.ClientCreatedAt,
+		ClockSkew:       event.ClockSkew,
+		SkewFlagged:     event.SkewFlagged,
+
+		ActorId:   event.ActorId,
+		Extension: event.Extension,
+
+		TestVersion: event.TestVersion,
+	}
+	if event.IdempotencyKey != "" {
+		key := event.IdempotencyKey
+		row.IdempotencyKey = &key
+	}
+	if event.Shuffle != nil {
+		shuffle, err := json.Marshal(event.Shuffle)
+		if err != nil {
+			return eventRow{}, err
+		}
+		row.Shuffle = shuffle
+	}
+	return row, nil
+}
+
+func (r eventRow) entity() (entities.Event, error) {
+	id, _ := primitive.ObjectIDFromHex(r.Id)
+	event := entities.Event{
+		Id:             id,
+		UserId:         r.UserId,
+		TestId:         r.TestId,
+		Event:          r.Event,
+		Session:        r.Session,
+		QuestionId:     r.QuestionId,
+		Answer:         r.Answer,
+		PreviousAnswer: r.PreviousAnswer,
+		Reason:         r.Reason,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+
+		ClientCreatedAt: r.ClientCreatedAt,
+		ClockSkew:       r.ClockSkew,
+		SkewFlagged:     r.SkewFlagged,
+
+		ActorId:   r.ActorId,
+		Extension: r.Extension,
+
+		TestVersion: r.TestVersion,
+	}
+	if r.IdempotencyKey != nil {
+		event.IdempotencyKey = *r.IdempotencyKey
+	}
+	if len(r.Shuffle) > 0 {
+		event.Shuffle = &entities.Shuffle{}
+		if err := json.Unmarshal(r.Shuffle, event.Shuffle); err != nil {
+			return entities.Event{}, err
+		}
+	}
+	return event, nil
+}
+
+type sessionRow struct {
+	Id           string     `gorm:"column:id;primaryKey"`
+	UserId       int        `gorm:"column:user_id"`
+	TestId       int        `gorm:"column:test_id"`
+	Attempt      int        `gorm:"column:attempt"`
+	State        string     `gorm:"column:state"`
+	Answers      []byte     `gorm:"column:answers"`
+	StartedAt    *time.Time `gorm:"column:started_at"`
+	EndedAt      *time.Time `gorm:"column:ended_at"`
+	Deadline     *time.Time `gorm:"column:deadline"`
+	StartEventId string     `gorm:"column:start_event_id"`
+	LastEventId  string     `gorm:"column:last_event_id"`
+	Version      int        `gorm:"column:version"`
+	UpdatedAt    *time.Time `gorm:"column:updated_at"`
+
+	Extension time.Duration `gorm:"column:extension"`
+	SkewFlags int           `gorm:"column:skew_flags"`
+	PausedAt  *time.Time    `gorm:"column:paused_at"`
+	Paused    time.Duration `gorm:"column:paused"`
+
+	TestVersion int    `gorm:"column:test_version"`
+	Shuffle     []byte `gorm:"column:shuffle"`
+	Flagged     []byte `gorm:"column:flagged"`
+}
+
+type sessionAnswer struct {
+	QuestionId int        `json:"question_id"`
+	Answer     string     `json:"answer"`
+	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+	EventId    string     `json:"event_id"`
+}
+
+func newSessionRow(session *entities.Session) (sessionRow, error) {
+	answers := make([]sessionAnswer, 0, len(session.Answers))
+	for _, item := range session.Answers {
+		answers = append(answers, sessionAnswer{
+			QuestionId: item.QuestionId,
+			Answer:     item.Answer,
+			AnsweredAt: item.AnsweredAt,
+			EventId:    item.EventId.Hex(),
+		})
+	}
+	b, err := json.Marshal(answers)
+	if err != nil {
+		return sessionRow{}, err
+	}
+	var shuffle []byte
+	if session.Shuffle != nil {
+		if shuffle, err = json.Marshal(session.Shuffle); err != nil {
+			return sessionRow{}, err
+		}
+	}
+	var flagged []byte
+	if len(session.Flagged) > 0 {
+		if flagged, err = json.Marshal(session.Flagged); err != nil {
+			return sessionRow{}, err
+		}
+	}
+	return sessionRow{
+		Id:           session.SessionId,
+		UserId:       session.UserId,
+		TestId:       session.TestId,
+		Attempt:      session.Attempt,
+		State:        session.State,
+		Answers:      b,
+		StartedAt:    session.StartedAt,
+		EndedAt:      session.EndedAt,
+		Deadline:     session.Deadline,
+		StartEventId: session.StartEventId.Hex(),
+		LastEventId:  session.LastEventId.Hex(),
+		Version:      session.Version,
+		UpdatedAt:    session.UpdatedAt,
+
+		Extension: session.Extension,
+		SkewFlags: session.SkewFlags,
+		PausedAt:  session.PausedAt,
+		Paused:    session.Paused,
+
+		TestVersion: session.TestVersion,
+		Shuffle:     shuffle,
+		Flagged:     flagged,
+	}, nil
+}
+
+func (r sessionRow) entity() (*entities.Session, error) {
+	var answers []sessionAnswer
+	if len(r.Answers) > 0 {
+		if err := json.Unmarshal(r.Answers, &answers); err != nil {
+			return nil, err
+		}
+	}
+	startEventId, _ := primitive.ObjectIDFromHex(r.StartEventId)
+	lastEventId, _ := primitive.ObjectIDFromHex(r.LastEventId)
+	session := &entities.Session{
+		SessionId:    r.Id,
+		UserId:       r.UserId,
+		TestId:       r.TestId,
+		Attempt:      r.Attempt,
+		State:        r.State,
+		Answers:      make(map[int]entities.SessionAnswer, len(answers)),
+		StartedAt:    r.StartedAt,
+		EndedAt:      r.EndedAt,
+		Deadline:     r.Deadline,
+		StartEventId: startEventId,
+		LastEventId:  lastEventId,
+		Version:      r.Version,
+		UpdatedAt:    r.UpdatedAt,
+
+		Extension: r.Extension,
+		SkewFlags: r.SkewFlags,
+		PausedAt:  r.PausedAt,
+		Paused:    r.Paused,
+
+		TestVersion: r.TestVersion,
+	}
+	if len(r.Shuffle) > 0 {
+		session.Shuffle = &entities.Shuffle{}
+		if err := json.Unmarshal(r.Shuffle, session.Shuffle); err != nil {
+			return nil, err
+		}
+	}
+	if len(r.Flagged) > 0 {
+		if err := json.Unmarshal(r.Flagged, &session.Flagged); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range answers {
+		eventId, _ := primitive.ObjectIDFromHex(item.EventId)
+		session.Answers[item.QuestionId] = entities.SessionAnswer{
+			QuestionId: item.QuestionId,
+			Answer:     item.Answer,
+			AnsweredAt: item.AnsweredAt,
+			EventId:    eventId,
+		}
+	}
+	return session, nil
+}
+
+// testRow keeps the whole test as json, the service only ever reads it back by
+// test id and version.
+type testRow struct {
+	TestId  int    `gorm:"column:test_id;primaryKey"`
+	Version int    `gorm:"column:version;primaryKey"`
+	Data    []byte `gorm:"column:data"`
+}
+
+type accommodationRow struct {
+	TestId       int        `gorm:"column:test_id;primaryKey"`
+	UserId       int        `gorm:"column:user_id;primaryKey"`
+	ExtraMinutes int        `gorm:"column:extra_minutes"`
+	Multiplier   float64    `gorm:"column:multiplier"`
+	UpdatedBy    int        `gorm:"column:updated_by"`
+	UpdatedAt    *time.Time `gorm:"column:updated_at"`
+}
+
+func newAccommodationRow(accommodation *entities.Accommodation) accommodationRow {
+	return accommodationRow{
+		TestId:       accommodation.TestId,
+		UserId:       accommodation.UserId,
+		ExtraMinutes: accommodation.ExtraMinutes,
+		Multiplier:   accommodation.Multiplier,
+		UpdatedBy:    accommodation.UpdatedBy,
+		UpdatedAt:    accommodation.UpdatedAt,
+	}
+}
+
+func (r accommodationRow) entity() *entities.Accommodation {
+	return &entities.Accommodation{
+		TestId:       r.TestId,
+		UserId:       r.UserId,
+		ExtraMinutes: r.ExtraMinutes,
+		Multiplier:   r.Multiplier,
+		UpdatedBy:    r.UpdatedBy,
+		UpdatedAt:    r.UpdatedAt,
+	}
+}
+
+type outboxRow struct {
+	Id          string     `gorm:"column:id;primaryKey"`
+	Topic       string     `gorm:"column:topic"`
+	Payload     []byte     `gorm:"column:payload"`
+	Status      string     `gorm:"column:status"`
+	Attempts    int        `gorm:"column:attempts"`
+	LastError   string     `gorm:"column:last_error"`
+	LockedUntil *time.Time `gorm:"column:locked_until"`
+	CreatedAt   *time.Time `gorm:"column:created_at"`
+	SentAt      *time.Time `gorm:"column:sent_at"`
+}
+
+func newOutboxRow(outbox *entities.Outbox) outboxRow {
+	return outboxRow{
+		Id:          outbox.Id.Hex(),
+		Topic:       outbox.Topic,
+		Payload:     outbox.Payload,
+		Status:      outbox.Status,
+		Attempts:    outbox.Attempts,
+		LastError:   outbox.LastError,
+		LockedUntil: outbox.LockedUntil,
+		CreatedAt:   outbox.CreatedAt,
+		SentAt:      outbox.SentAt,
+	}
+}
+
+func (r outboxRow) entity() entities.Outbox {
+	id, _ := primitive.ObjectIDFromHex(r.Id)
+	return entities.Outbox{
+		Id:          id,
+		Topic:       r.Topic,
+		Payload:     r.Payload,
+		Status:      r.Status,
+		Attempts:    r.Attempts,
+		LastError:   r.LastError,
+		LockedUntil: r.LockedUntil,
+		CreatedAt:   r.CreatedAt,
+		SentAt:      r.SentAt,
+	}
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"gorm.io/gorm"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+type outboxRepository struct {
+	db    *gorm.DB
+	table string
+}
+
+func NewOutboxRepository(config config.IConfig) *outboxRepository {
+	return &outboxRepository{db: config.GetPostgres(), table: config.GetOutboxCollection()}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, outbox *entities.Outbox) error {
+	row := newOutboxRow(outbox)
+	return r.db.WithContext(ctx).Table(r.table).Create(&row).Error
+}
+
+// ClaimPending leases up to limit pending rows in one statement, SKIP LOCKED
+// lets concurrent relays claim disjoint rows.
+func (r *outboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]entities.Outbox, error) {
+	now := time.Now()
+	query := fmt.Sprintf(`UPDATE %[1]s SET locked_until = ?, attempts = attempts + 1
+WHERE id IN (
+	SELECT id FROM %[1]s
+	WHERE status = ? AND (locked_until IS NULL OR locked_until < ?)
+	ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED
+)
+RETURNING *`, r.table)
+
+	var rows []outboxRow
+	if err := r.db.WithContext(ctx).Raw(query, now.Add(lease), entities.OUTBOX_PENDING, now, limit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	result := make([]entities.Outbox, len(rows))
+	for index, row := range rows {
+		result[index] = row.entity()
+	}
+	return result, nil
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, id primitive.ObjectID) error {
+	return r.db.WithContext(ctx).Table(r.table).Where("id = ?", id.Hex()).Updates(map[string]interface{}{
+		"status":       entities.OUTBOX_SENT,
+		"sent_at":      time.Now(),
+		"locked_until": nil,
+		"last_error":   "",
+	}).Error
+}
+
+func (r *outboxRepository) Release(ctx context.Context, id primitive.ObjectID, errMessage string) error {
+	return r.db.WithContext(ctx).Table(r.table).Where("id = ?", id.Hex()).Updates(map[string]interface{}{
+		"last_error":   errMessage,
+		"locked_until": nil,
+	}).Error
+}
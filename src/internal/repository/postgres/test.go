@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"gorm.io/gorm"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/entities"
+)
+
+type testRepository struct {
+	db    *gorm.DB
+	table string
+}
+
+func NewTestRepository(config config.IConfig) *testRepository {
+	return &testRepository{db: config.GetPostgres(), table: config.GetTestsCollection()}
+}
+
+func (r *testRepository) Create(ctx context.Context, test *entities.Test) error {
+	b, err := json.Marshal(test)
+	if err != nil {
+		return err
+	}
+	row := testRow{TestId: test.TestId, Version: test.Version, Data: b}
+	return r.db.WithContext(ctx).Table(r.table).Create(&row).Error
+}
+
+// FindByTestId returns the newest version of the test.
+func (r *testRepository) FindByTestId(ctx context.Context, testId int) (*entities.Test, error) {
+	return r.find(r.db.WithContext(ctx).Table(r.table).Where("test_id = ?", testId).Order("version DESC"))
+}
+
+func (r *testRepository) FindByTestIdAndVersion(ctx context.Context, testId int, version int) (*entities.Test, error) {
+	return r.find(r.db.WithContext(ctx).Table(r.table).Where("test_id = ? AND version = ?", testId, version))
+}
+
+func (r *testRepository) find(query *gorm.DB) (*entities.Test, error) {
+	var row testRow
+	if err := query.Take(&row).Error; err != nil {
+		return nil, mapError(err)
+	}
+	var test entities.Test
+	if err := json.Unmarshal(row.Data, &test); err != nil {
+		return nil, err
+	}
+	return &test, nil
+}
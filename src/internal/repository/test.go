@@ -4,21 +4,22 @@ import (
 	"context"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"picket-answersheet-service/src/config"
 	"picket-answersheet-service/src/internal/entities"
 )
 
 type testRepository struct {
-	mongo *mongo.Client
+	collection *mongo.Collection
 }
 
-func NewTestRepository(mongo *mongo.Client) *testRepository {
-	return &testRepository{mongo: mongo}
+func NewTestRepository(config config.IConfig) *testRepository {
+	database := config.GetMongo().Database(config.GetMongoDatabase())
+	return &testRepository{collection: database.Collection(config.GetTestsCollection())}
 }
 
 func (r *testRepository) Create(ctx context.Context, test *entities.Test) error {
-	collection := r.mongo.Database("picket").Collection("tests")
-
-	_, err := collection.InsertOne(ctx, test)
+	_, err := r.collection.InsertOne(ctx, test)
 	if err != nil {
 		return err
 	}
@@ -26,18 +27,24 @@ func (r *testRepository) Create(ctx context.Context, test *entities.Test) error
 	return nil
 }
 
+// FindByTestId returns the newest version of the test.
 func (r *testRepository) FindByTestId(ctx context.Context, testId int) (*entities.Test, error) {
-	filter := bson.M{
-		"test_id": testId,
-	}
-	result := r.mongo.Database("picket").Collection("tests").FindOne(ctx, filter)
+	opts := options.FindOne().SetSort(bson.D{{"version", -1}, {"_id", -1}})
+	return r.find(ctx, bson.M{"test_id": testId}, opts)
+}
+
+func (r *testRepository) FindByTestIdAndVersion(ctx context.Context, testId int, version int) (*entities.Test, error) {
+	return r.find(ctx, bson.M{"test_id": testId, "version": version})
+}
+
+func (r *testRepository) find(ctx context.Context, filter bson.M, opts ...*options.FindOneOptions) (*entities.Test, error) {
+	result := r.collection.FindOne(ctx, filter, opts...)
 	if result.Err() != nil {
-		return nil, result.Err()
+		return nil, mapError(result.Err())
 	}
 	var test entities.Test
 	if err := result.Decode(&test); err != nil {
 		return nil, err
 	}
 	return &test, nil
-
 }
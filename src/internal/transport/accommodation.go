@@ -0,0 +1,107 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"github.com/avast/retry-go"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/codec"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"picket-answersheet-service/src/internal/usecase"
+	answersheetpb "picket-answersheet-service/src/pb/answer_sheet"
+)
+
+type IAccommodationUsecase interface {
+	SetAccommodation(ctx context.Context, input dto.AccommodationInput) (*entities.Accommodation, error)
+}
+
+type AccommodationTransport struct {
+	config  config.IConfig
+	usecase IAccommodationUsecase
+	broker  IBroker
+}
+
+func NewAccommodationTransport(ctx context.Context, usecase IAccommodationUsecase, config config.IConfig, broker IBroker) *AccommodationTransport {
+	t := &AccommodationTransport{
+		usecase: usecase,
+		config:  config,
+		broker:  broker,
+	}
+
+	go t.SyncAccommodation(ctx)
+
+	return t
+}
+
+// SyncAccommodation stores the accommodations published by the admin service.
+// An invalid message is logged and skipped.
+func (t *AccommodationTransport) SyncAccommodation(ctx context.Context) {
+	r := t.broker.Reader("accommodations", "accommodations-1")
+	defer func() {
+		if err := r.Close(); err != nil {
+			log.Error().Err(err).Send()
+		}
+	}()
+	for {
+		m, err := r.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error().Err(err).Send()
+			continue
+		}
+		var input dto.AccommodationInput
+		if err := codec.DecodeStrict(m.Value, &input); err != nil {
+			log.Error().Err(err).Str("topic", m.Topic).Send()
+			r.CommitMessages(ctx, m)
+			continue
+		}
+		err = retry.Do(func() error {
+			_, err := t.usecase.SetAccommodation(ctx, input)
+			return err
+		}, retry.Context(ctx), retry.RetryIf(isRetryable), retry.LastErrorOnly(true))
+		if err != nil {
+			log.Error().Err(err).Interface("accommodation", input).Msg("set accommodation")
+		}
+		if err := r.CommitMessages(ctx, m); err != nil {
+			log.Error().Err(err).Send()
+		}
+	}
+}
+
+func (t *answersheetTransport) SetAccommodation(ctx context.Context, request *answersheetpb.SetAccommodationRequest) (*answersheetpb.Accommodation, error) {
+	accommodation, err := t.accommodations.SetAccommodation(ctx, dto.AccommodationInput{
+		TestId:       int(request.TestId),
+		UserId:       int(request.UserId),
+		ExtraMinutes: int(request.ExtraMinutes),
+		Multiplier:   request.Multiplier,
+		UpdatedBy:    int(request.UpdatedBy),
+	})
+	var validationErr *usecase.ValidationError
+	if errors.As(err, &validationErr) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, entities.ErrVersionConflict) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "server has error")
+	}
+	result := &answersheetpb.Accommodation{
+		TestId:       int64(accommodation.TestId),
+		UserId:       int64(accommodation.UserId),
+		ExtraMinutes: int32(accommodation.ExtraMinutes),
+		Multiplier:   accommodation.Multiplier,
+		UpdatedBy:    int64(accommodation.UpdatedBy),
+	}
+	if accommodation.UpdatedAt != nil {
+		result.UpdatedAt = timestamppb.New(*accommodation.UpdatedAt)
+	}
+	return result, nil
+}
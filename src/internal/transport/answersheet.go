@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/avast/retry-go"
 	"github.com/rs/zerolog/log"
 	"github.com/segmentio/kafka-go"
@@ -12,256 +13,445 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/broker"
+	"picket-answersheet-service/src/internal/codec"
 	"picket-answersheet-service/src/internal/dto"
 	"picket-answersheet-service/src/internal/entities"
 	"picket-answersheet-service/src/internal/usecase"
 	answersheetpb "picket-answersheet-service/src/pb/answer_sheet"
-	"strings"
+	"sort"
 	"time"
 )
 
 type IAnswerSheetUsecase interface {
-	StartTest(ctx context.Context, input dto.StartTestInput) error
-	UserAnswer(ctx context.Context, input dto.UserAnswerInput) error
-	PushToDeadLetterQueue(ctx context.Context, value []byte) error
-	CheckUserDoingTest(ctx context.Context, userId int, testId int) (bool, error)
+	StartTest(ctx context.Context, input dto.StartTestInput) (*entities.Event, error)
+	UserAnswer(ctx context.Context, input dto.UserAnswerInput) (*entities.Event, error)
+	PushToDeadLetterQueue(ctx context.Context, message kafka.Message, reason error) error
+	CheckUserDoingTest(ctx context.Context, userId int, testId int) (*dto.SessionStatusOutput, error)
 	NotifyJobSuccess(ctx context.Context, jobId int) error
 	NotifyJobFail(ctx context.Context, jobId int, errFail error) error
-	GetLatestStartTime(ctx context.Context, testId int, userId int) (*time.Time, error)
-	GetCurrentTest(ctx context.Context, testId int, userId int) ([]entities.Event, error)
+	GetLatestStartTime(ctx context.Context, testId int, userId int) (*dto.StartTimeOutput, error)
+	GetCurrentTest(ctx context.Context, testId int, userId int) (*dto.CurrentTestOutput, error)
+	ResumeSession(ctx context.Context, userId int, testId int) (*dto.ResumeSessionOutput, error)
+	FlagQuestion(ctx context.Context, input dto.FlagQuestionInput) (*entities.Event, error)
+	UnflagQuestion(ctx context.Context, input dto.FlagQuestionInput) (*entities.Event, error)
+	GetSubmitSummary(ctx context.Context, userId int, testId int) (*dto.SubmitSummaryOutput, error)
 	CheckUserSubmitted(ctx context.Context, userId int, testId int) (bool, error)
-	SubmitTest(ctx context.Context, input dto.SubmitTestInput) error
+	SubmitTest(ctx context.Context, input dto.SubmitTestInput) (*entities.Event, error)
 	GetScore(ctx context.Context, input dto.GetScoreInput) (*dto.GetScoreOutput, error)
+	ExtendTime(ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error)
+	ForceSubmit(ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error)
+	VoidSession(ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error)
+	ProctorPause(ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error)
+	ProctorResume(ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error)
+}
+
+type IWatchUsecase interface {
+	WatchSession(ctx context.Context, userId int, testId int, send func(update entities.SessionUpdate) error) error
+	ListActiveSessions(ctx context.Context, testId int) ([]entities.Session, error)
+	WatchTest(ctx context.Context, testId int, send func(update entities.SessionUpdate) error) error
 }
 
 type answersheetTransport struct {
-	usecase IAnswerSheetUsecase
+	usecase        IAnswerSheetUsecase
+	watch          IWatchUsecase
+	accommodations IAccommodationUsecase
 	answersheetpb.UnimplementedAnswerSheetServiceServer
-	config config.IConfig
+	config     config.IConfig
+	dispatcher *dispatcher
+	codecs     ICodecRegistry
+	broker     IBroker
+	// done closes when the server stops, so open streams end and let
+	// GracefulStop return
+	done <-chan struct{}
 }
 
-func NewAnswerSheetTransport(ctx context.Context, usecase IAnswerSheetUsecase, iConfig config.IConfig) *answersheetTransport {
-	t := answersheetTransport{usecase: usecase, config: iConfig}
-	go t.StartTest(ctx)
-	go func() {
-		for i := 1; i <= 10; i++ {
-			go t.UserAnswer(ctx)
-		}
-	}()
-	go t.SubmitTest(ctx)
+type ICodecRegistry interface {
+	Decode(m kafka.Message) (*dto.EventEnvelope, error)
+}
+
+type IBroker interface {
+	Reader(topic string, groupId string) broker.IReader
+}
+
+func NewAnswerSheetTransport(ctx context.Context, usecase IAnswerSheetUsecase, watch IWatchUsecase, accommodations IAccommodationUsecase, iConfig config.IConfig, codecs ICodecRegistry, broker IBroker) *answersheetTransport {
+	t := answersheetTransport{usecase: usecase, watch: watch, accommodations: accommodations, config: iConfig, codecs: codecs, broker: broker, done: ctx.Done()}
+	t.dispatcher = newDispatcher(ctx, iConfig.GetDispatcherWorkers(), iConfig.GetDispatcherReorderWindow(), iConfig.GetDispatcherParkTimeout(), isSessionNotStarted)
+
+	for i := 0; i < iConfig.GetKafkaEventWorkers(); i++ {
+		go t.Events(ctx)
+	}
+	for i := 0; i < iConfig.GetKafkaStartWorkers(); i++ {
+		go t.StartTest(ctx)
+	}
+	for i := 0; i < iConfig.GetKafkaAnswerWorkers(); i++ {
+		go t.UserAnswer(ctx)
+	}
+	for i := 0; i < iConfig.GetKafkaSubmitWorkers(); i++ {
+		go t.SubmitTest(ctx)
+	}
 	return &t
 }
 
-func (t *answersheetTransport) UserAnswer(ctx context.Context) {
-	r := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: strings.Split(t.config.GetKafkaBroker(), ","),
-		Topic:   "answer-test",
-		GroupID: "answer-test-2",
-		//Logger:  kafka.LoggerFunc(log.Info().Msgf),
-	})
+func (t *answersheetTransport) Events(ctx context.Context) {
+	t.consume(ctx, "answersheet-events", "answersheet-events-1", t.codecs.Decode)
+}
 
-	for {
-		m, err := r.FetchMessage(ctx)
-		log.Info().Str("message", string(m.Value)).Str("topic", m.Topic).Msg("fetch message")
-		if err != nil {
-			log.Error().Err(err).Send()
-			continue
-		}
+// UserAnswer, SubmitTest and StartTest adapt the legacy per-event topics to the
+// envelope used by answersheet-events; they can go away once every producer
+// has moved to the new topic.
+func (t *answersheetTransport) UserAnswer(ctx context.Context) {
+	t.consume(ctx, "answer-test", "answer-test-2", func(m kafka.Message) (*dto.EventEnvelope, error) {
 		var input dto.UserAnswerInput
-		if err := json.NewDecoder(bytes.NewBuffer(m.Value)).Decode(&input); err != nil {
-			log.Error().Err(err).Send()
-			r.CommitMessages(ctx, m)
-			continue
+		if err := decodeLegacy(m, &input); err != nil {
+			return nil, err
 		}
+		envelope, err := input.Envelope()
+		if err != nil {
+			return nil, err
+		}
+		return &envelope, nil
+	})
+}
 
-		if input.JobId == 0 {
-			log.Error().Interface("payload", input.Payload).Msg("message not valid")
-			r.CommitMessages(ctx, m)
-			continue
+func (t *answersheetTransport) SubmitTest(ctx context.Context) {
+	t.consume(ctx, "submit-test", "submit-test-1", func(m kafka.Message) (*dto.EventEnvelope, error) {
+		var input dto.SubmitTestInput
+		if err := decodeLegacy(m, &input); err != nil {
+			return nil, err
 		}
+		envelope := input.Envelope()
+		return &envelope, nil
+	})
+}
 
-		err = retry.Do(func() error {
-			return t.usecase.UserAnswer(ctx, input)
-		}, retry.Attempts(10))
-		if err != nil {
-			log.Error().Err(err).Send()
-			continue
+func (t *answersheetTransport) StartTest(ctx context.Context) {
+	t.consume(ctx, "start-test", "start-test-1", func(m kafka.Message) (*dto.EventEnvelope, error) {
+		var input dto.StartTestInput
+		if err := decodeLegacy(m, &input); err != nil {
+			return nil, err
 		}
+		envelope := input.Envelope()
+		return &envelope, nil
+	})
+}
 
-		go retry.Do(func() error {
-			return t.usecase.NotifyJobSuccess(ctx, input.JobId)
-		}, retry.Attempts(20))
+// decodeLegacy reads a legacy payload as leniently as those topics always
+// were; unknown fields are only logged, so the producers that still send them
+// can be found before the topics go away.
+func decodeLegacy(m kafka.Message, v interface{}) error {
+	if err := json.NewDecoder(bytes.NewBuffer(m.Value)).Decode(v); err != nil {
+		return err
+	}
+	if err := codec.DecodeStrict(m.Value, v); err != nil {
+		log.Warn().Err(err).Str("topic", m.Topic).Msg("legacy message has unknown fields")
+	}
+	return nil
+}
 
-		if err := r.CommitMessages(ctx, m); err != nil {
-			log.Error().Err(err).Send()
-			continue
-		}
+// eventPhase ranks the events of a session for the dispatcher: a START runs
+// before the events read from other topics, an END after them.
+func eventPhase(event string) int {
+	switch event {
+	case entities.START:
+		return 0
+	case entities.END:
+		return 2
+	default:
+		return 1
 	}
 }
 
-func (t *answersheetTransport) SubmitTest(ctx context.Context) {
-	r := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: strings.Split(t.config.GetKafkaBroker(), ","),
-		Topic:   "submit-test",
-		GroupID: "submit-test-1",
-		//Logger:  kafka.LoggerFunc(log.Info().Msgf),
-	})
-	defer func() {
-		if err := r.Close(); err != nil {
-			log.Error().Err(err).Send()
-		}
-	}()
+func isSessionNotStarted(err error) bool {
+	return errors.Is(err, usecase.ErrSessionNotStarted)
+}
 
-	for {
-		m, err := r.FetchMessage(ctx)
+// isAlreadyDone reports errors meaning the session is already in the state the
+// job asked for, the job succeeds without writing anything.
+func isAlreadyDone(err error) bool {
+	return errors.Is(err, usecase.ErrUserSubmitted) || errors.Is(err, usecase.ErrSessionInProgress)
+}
+
+func isRetryable(err error) bool {
+	var transitionErr *entities.TransitionError
+	var afterSubmitErr *usecase.AnswerAfterSubmitError
+	var validationErr *usecase.ValidationError
+	return !isAlreadyDone(err) && !isSessionNotStarted(err) && !errors.As(err, &transitionErr) &&
+		!errors.As(err, &afterSubmitErr) && !errors.As(err, &validationErr)
+}
+
+func (t *answersheetTransport) process(ctx context.Context, envelope *dto.EventEnvelope) (*entities.Event, error) {
+	switch envelope.Type {
+	case entities.START:
+		return t.usecase.StartTest(ctx, envelope.StartTestInput())
+	case entities.ANSWER:
+		input, err := envelope.UserAnswerInput()
 		if err != nil {
-			log.Error().Err(err).Send()
-			continue
+			return nil, err
 		}
-		var input dto.SubmitTestInput
-		if err := json.NewDecoder(bytes.NewBuffer(m.Value)).Decode(&input); err != nil {
-			log.Error().Err(err).Send()
-			r.CommitMessages(ctx, m)
-			continue
-		}
-		if input.JobId == 0 {
-			log.Error().Interface("payload", input.Payload).Msg("job_id = 0")
-			r.CommitMessages(ctx, m)
-			continue
-		}
-		log.Info().Str("topic", "submit-test").Interface("payload", input).Send()
-		err = retry.Do(func() error {
-			return t.usecase.SubmitTest(ctx, input)
-		}, retry.Attempts(10))
+		return t.usecase.UserAnswer(ctx, input)
+	case entities.END:
+		return t.usecase.SubmitTest(ctx, envelope.SubmitTestInput())
+	case entities.FLAG, entities.UNFLAG:
+		input, err := envelope.FlagQuestionInput()
 		if err != nil {
-			log.Error().Err(err).Send()
-			continue
+			return nil, err
 		}
-
-		go retry.Do(func() error {
-			err := t.usecase.NotifyJobSuccess(ctx, input.JobId)
-			if err != nil && errors.Is(err, usecase.ErrUserSubmitted) {
-				return nil
-			}
-			return err
-		}, retry.Attempts(20))
-		if err := r.CommitMessages(ctx, m); err != nil {
-			log.Error().Err(err).Send()
-			continue
+		if envelope.Type == entities.UNFLAG {
+			return t.usecase.UnflagQuestion(ctx, input)
 		}
+		return t.usecase.FlagQuestion(ctx, input)
+	case entities.PAUSE, entities.RESUME:
+		// only a proctor stops the clock of a session, through PauseTest
+		return nil, &usecase.ValidationError{Fields: []usecase.FieldError{{Field: "type", Message: "PAUSE and RESUME are proctor actions"}}}
+	default:
+		return nil, fmt.Errorf("unknown event type %q", envelope.Type)
 	}
-
 }
 
-func (t *answersheetTransport) StartTest(ctx context.Context) {
-	r := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: strings.Split(t.config.GetKafkaBroker(), ","),
-		Topic:   "start-test",
-		GroupID: "start-test-1",
-		//Logger:  kafka.LoggerFunc(log.Info().Msgf),
-	})
-
+func (t *answersheetTransport) consume(ctx context.Context, topic string, groupId string, decode func(m kafka.Message) (*dto.EventEnvelope, error)) {
+	r := t.broker.Reader(topic, groupId)
 	defer func() {
 		if err := r.Close(); err != nil {
 			log.Error().Err(err).Send()
 		}
 	}()
+
 	for {
 		m, err := r.FetchMessage(ctx)
-		log.Info().Str("message", string(m.Value)).Str("topic", m.Topic).Msg("fetch message")
-
 		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
 			log.Error().Err(err).Send()
 			continue
 		}
-		var input dto.StartTestInput
-		// message json khong hop le
-		if err := json.NewDecoder(bytes.NewBuffer(m.Value)).Decode(&input); err != nil {
-			log.Error().Err(err).Send()
+		log.Info().Str("message", string(m.Value)).Str("topic", m.Topic).Msg("fetch message")
+
+		// message khong hop le, dua vao dead letter queue
+		envelope, err := decode(m)
+		if err != nil {
+			log.Error().Err(err).Str("topic", m.Topic).Msg("decode message")
+			if err := t.deadLetter(ctx, m, envelope, err); err != nil {
+				log.Error().Err(err).Msg("push to dead letter queue")
+				continue
+			}
 			r.CommitMessages(ctx, m)
 			continue
 		}
 		// message chua duoc insert
-		if input.JobId == 0 {
-			log.Error().Interface("payload", input.Payload).Msg("message not valid")
+		if envelope.JobId == 0 {
+			log.Error().Interface("payload", envelope).Msg("message not valid")
 			r.CommitMessages(ctx, m)
 			continue
 		}
 
-		// retry start
-		err = retry.Do(func() error {
-			return t.usecase.StartTest(ctx, input)
-		}, retry.Attempts(10))
-		if err != nil {
+		order := dispatchOrder{topic: m.Topic, partition: m.Partition, offset: m.Offset, phase: eventPhase(envelope.Type)}
+		err = t.dispatcher.Dispatch(ctx, envelope.Key(), order, func(ctx context.Context) error {
+			return retry.Do(func() error {
+				_, err := t.process(ctx, envelope)
+				return err
+			}, retry.Attempts(10), retry.Context(ctx), retry.RetryIf(isRetryable), retry.LastErrorOnly(true))
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if isAlreadyDone(err) {
+			if err := t.notifyJobSuccess(ctx, envelope.JobId); err != nil {
+				log.Error().Err(err).Int("job_id", envelope.JobId).Msg("notify job success")
+				continue
+			}
+		} else if err != nil {
 			log.Error().Err(err).Send()
-			continue
+			if err := t.notifyJobFail(ctx, envelope.JobId, err); err != nil {
+				log.Error().Err(err).Int("job_id", envelope.JobId).Msg("notify job fail")
+				continue
+			}
 		}
 
-		go retry.Do(func() error {
-			return t.usecase.NotifyJobSuccess(ctx, input.JobId)
-		}, retry.Attempts(20))
-
 		if err := r.CommitMessages(ctx, m); err != nil {
 			log.Error().Err(err).Send()
 			continue
 		}
 	}
+}
 
+// deadLetter parks an undecodable message; when the envelope could still be
+// read the job is failed too so the upstream job does not wait forever.
+func (t *answersheetTransport) deadLetter(ctx context.Context, m kafka.Message, envelope *dto.EventEnvelope, reason error) error {
+	err := retry.Do(func() error {
+		return t.usecase.PushToDeadLetterQueue(ctx, m, reason)
+	}, retry.Attempts(3), retry.Context(ctx))
+	if err != nil {
+		return err
+	}
+	if envelope == nil || envelope.JobId == 0 {
+		return nil
+	}
+	return t.notifyJobFail(ctx, envelope.JobId, reason)
+}
+
+// notifyAttempts bounds the retries of a job notification; the message is
+// left uncommitted when they run out, so it only comes back on a rebalance.
+const notifyAttempts = 20
+
+func (t *answersheetTransport) notifyJobSuccess(ctx context.Context, jobId int) error {
+	return retry.Do(func() error {
+		return t.usecase.NotifyJobSuccess(ctx, jobId)
+	}, retry.Attempts(notifyAttempts), retry.Context(ctx))
+}
+
+func (t *answersheetTransport) notifyJobFail(ctx context.Context, jobId int, errFail error) error {
+	return retry.Do(func() error {
+		return t.usecase.NotifyJobFail(ctx, jobId, errFail)
+	}, retry.Attempts(notifyAttempts), retry.Context(ctx))
 }
 
 func (t *answersheetTransport) CheckUserDoingTest(ctx context.Context, request *answersheetpb.CheckUserDoingTestRequest) (*answersheetpb.CheckUserDoingTestResponse, error) {
 
-	check, err := t.usecase.CheckUserDoingTest(ctx, int(request.UserId), int(request.TestId))
+	result, err := t.usecase.CheckUserDoingTest(ctx, int(request.UserId), int(request.TestId))
 	if err != nil {
 		return nil, status.Error(codes.Internal, "server has error")
 	}
 
 	return &answersheetpb.CheckUserDoingTestResponse{
-		Check:   check,
+		Check:   result.Doing,
 		Message: "success",
+		State:   result.State,
 	}, nil
 }
 
 func (t *answersheetTransport) GetLatestStartTime(ctx context.Context, request *answersheetpb.GetLatestStartTimeRequest) (*answersheetpb.GetLatestStartTimeResponse, error) {
 	result, err := t.usecase.GetLatestStartTime(ctx, int(request.TestId), int(request.UserId))
-
 	if err != nil {
-		panic(err)
+		log.Error().Err(err).Int64("user_id", request.UserId).Int64("test_id", request.TestId).Msg("get latest start time")
+		return nil, status.Error(codes.Internal, "server has error")
 	}
 
 	resp := answersheetpb.GetLatestStartTimeResponse{
 		Message: "success1",
 	}
 
-	if result != nil {
+	if result.StartedAt != nil {
 		//zap.S().Info(result.Format("15:04:05 02/01/2006"))
-		resp.Data = timestamppb.New(*result)
+		resp.Data = timestamppb.New(*result.StartedAt)
 	} else {
 		resp.Data = nil
 	}
+	if result.Deadline != nil {
+		resp.Deadline = timestamppb.New(*result.Deadline)
+		resp.RemainingSeconds = int64(result.Remaining / time.Second)
+	}
 
 	return &resp, nil
 }
 
 func (t *answersheetTransport) GetCurrentTest(ctx context.Context, request *answersheetpb.GetCurrentTestRequest) (*answersheetpb.GetCurrentTestResponse, error) {
-	data, err := t.usecase.GetCurrentTest(ctx, int(request.TestId), int(request.UserId))
+	result, err := t.usecase.GetCurrentTest(ctx, int(request.TestId), int(request.UserId))
 	if err != nil {
-		panic(err)
+		return nil, sessionError(err, "get current test")
 	}
-	list := make([]*answersheetpb.Answer, len(data))
-	for index, item := range data {
-		list[index] = &answersheetpb.Answer{
-			QuestionId: int64(item.QuestionId),
-			Answer:     item.Answer,
-		}
+	list := make([]*answersheetpb.Answer, len(result.Answers))
+	for index, item := range result.Answers {
+		list[index] = newAnswer(entities.SessionAnswer{QuestionId: item.QuestionId, Answer: item.Answer, AnsweredAt: item.CreatedAt})
 	}
 	resp := answersheetpb.GetCurrentTestResponse{
 		Message: "success",
 		Data:    list,
+		Flagged: int64s(result.Flagged),
+	}
+	if result.Shuffle != nil {
+		resp.QuestionOrder = int64s(result.Shuffle.QuestionOrder())
+		resp.Options = newQuestionOptions(result.Shuffle.Options)
 	}
 	return &resp, nil
 }
 
+func (t *answersheetTransport) ResumeSession(ctx context.Context, request *answersheetpb.ResumeSessionRequest) (*answersheetpb.ResumeSessionResponse, error) {
+	result, err := t.usecase.ResumeSession(ctx, int(request.UserId), int(request.TestId))
+	if err != nil {
+		return nil, sessionError(err, "resume session")
+	}
+	session := result.Session
+	resp := &answersheetpb.ResumeSessionResponse{
+		Session:       session.SessionId,
+		Attempt:       int32(session.Attempt),
+		TestVersion:   int64(session.TestVersion),
+		State:         result.State,
+		Answers:       make([]*answersheetpb.Answer, len(result.Answers)),
+		Flagged:       int64s(result.Flagged),
+		QuestionOrder: int64s(result.QuestionOrder),
+		Options:       newQuestionOptions(result.Options),
+		ServerTime:    timestamppb.New(result.ServerTime),
+		Version:       int64(session.Version),
+	}
+	for index, item := range result.Answers {
+		resp.Answers[index] = newAnswer(item)
+	}
+	if session.StartedAt != nil {
+		resp.StartedAt = timestamppb.New(*session.StartedAt)
+	}
+	if session.Deadline != nil {
+		resp.Deadline = timestamppb.New(*session.Deadline)
+		resp.RemainingSeconds = int64(result.Remaining / time.Second)
+	}
+	return resp, nil
+}
+
+func (t *answersheetTransport) GetSubmitSummary(ctx context.Context, request *answersheetpb.GetSubmitSummaryRequest) (*answersheetpb.GetSubmitSummaryResponse, error) {
+	result, err := t.usecase.GetSubmitSummary(ctx, int(request.UserId), int(request.TestId))
+	if err != nil {
+		return nil, sessionError(err, "get submit summary")
+	}
+	return &answersheetpb.GetSubmitSummaryResponse{
+		Session:          result.Session.SessionId,
+		State:            result.State,
+		Total:            int32(result.Total),
+		Answered:         int32(result.Answered),
+		Unanswered:       int64s(result.Unanswered),
+		Flagged:          int64s(result.Flagged),
+		RemainingSeconds: int64(result.Remaining / time.Second),
+	}, nil
+}
+
+// sessionError maps the errors of reading the latest session of a user, one
+// who never started gets NotFound.
+func sessionError(err error, msg string) error {
+	if errors.Is(err, usecase.ErrSessionNotStarted) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	log.Error().Err(err).Msg(msg)
+	return status.Error(codes.Internal, "server has error")
+}
+
+func newAnswer(answer entities.SessionAnswer) *answersheetpb.Answer {
+	result := &answersheetpb.Answer{QuestionId: int64(answer.QuestionId), Answer: answer.Answer}
+	if answer.AnsweredAt != nil {
+		result.AnsweredAt = timestamppb.New(*answer.AnsweredAt)
+	}
+	return result
+}
+
+// newQuestionOptions lists the option orders by question id.
+func newQuestionOptions(options map[int][]string) []*answersheetpb.QuestionOptions {
+	result := make([]*answersheetpb.QuestionOptions, 0, len(options))
+	for questionId, item := range options {
+		result = append(result, &answersheetpb.QuestionOptions{QuestionId: int64(questionId), Options: item})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].QuestionId < result[j].QuestionId
+	})
+	return result
+}
+
+func int64s(values []int) []int64 {
+	result := make([]int64, len(values))
+	for index, value := range values {
+		result[index] = int64(value)
+	}
+	return result
+}
+
 func (t *answersheetTransport) CheckUserSubmitted(ctx context.Context, request *answersheetpb.CheckUserSubmittedRequest) (*answersheetpb.CheckUserSubmittedResponse, error) {
 	check, err := t.usecase.CheckUserSubmitted(ctx, int(request.UserId), int(request.TestId))
 	if err != nil {
@@ -290,3 +480,37 @@ func (t *answersheetTransport) GetScore(ctx context.Context, request *answershee
 }
 
 // KAFKA
+
+func (t *answersheetTransport) WatchSession(request *answersheetpb.WatchSessionRequest, stream answersheetpb.AnswerSheetService_WatchSessionServer) error {
+	ctx, cancel := t.streamContext(stream.Context())
+	defer cancel()
+
+	err := t.watch.WatchSession(ctx, int(request.UserId), int(request.TestId), func(update entities.SessionUpdate) error {
+		return stream.Send(newSessionUpdate(update))
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("user_id", request.UserId).Int64("test_id", request.TestId).Msg("watch session")
+		return status.Error(codes.Internal, "server has error")
+	}
+	return nil
+}
+
+func newSessionUpdate(update entities.SessionUpdate) *answersheetpb.SessionUpdate {
+	session := update.Session
+	result := &answersheetpb.SessionUpdate{
+		Type:       update.Type,
+		Session:    session.SessionId,
+		State:      session.StateAt(update.At),
+		OccurredAt: timestamppb.New(update.At),
+		Version:    int64(session.Version),
+		Flagged:    int64s(session.Flagged),
+	}
+	if update.Answer != nil {
+		result.Answer = newAnswer(*update.Answer)
+	}
+	if remaining, ok := session.Remaining(update.At); ok && (session.State == entities.SESSION_IN_PROGRESS || session.State == entities.SESSION_PAUSED) {
+		result.Deadline = timestamppb.New(*session.Deadline)
+		result.RemainingSeconds = int64(remaining / time.Second)
+	}
+	return result
+}
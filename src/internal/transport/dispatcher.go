@@ -0,0 +1,186 @@
+package transport
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+)
+
+const parkBackoff = 200 * time.Millisecond
+
+// dispatchOrder places a job in its key queue. Jobs read from the same topic
+// partition keep their offset order; jobs from different topics only compare
+// by the phase of their event, since nothing relates offsets or job ids
+// across topics.
+type dispatchOrder struct {
+	topic     string
+	partition int
+	offset    int64
+	phase     int
+}
+
+func (o dispatchOrder) before(other dispatchOrder) bool {
+	if o.topic == other.topic && o.partition == other.partition {
+		return o.offset < other.offset
+	}
+	return o.phase < other.phase
+}
+
+type dispatchJob struct {
+	key     string
+	order   dispatchOrder
+	handle  func(ctx context.Context) error
+	done    chan error
+	arrived time.Time
+	readyAt time.Time
+	running bool
+}
+
+type dispatchResult struct {
+	job *dispatchJob
+	err error
+}
+
+// dispatcher serializes jobs that share a key (user and test) while jobs of
+// different keys run in parallel. Each job is held for the reorder window so
+// that messages fetched from different topics can be put in order before they
+// are handled, and a job whose error satisfies park is retried at the head of
+// its key queue until parkTimeout has elapsed.
+type dispatcher struct {
+	shards      []chan *dispatchJob
+	window      time.Duration
+	parkTimeout time.Duration
+	park        func(err error) bool
+}
+
+func newDispatcher(ctx context.Context, workers int, window time.Duration, parkTimeout time.Duration, park func(err error) bool) *dispatcher {
+	if workers <= 0 {
+		workers = 1
+	}
+	d := &dispatcher{
+		shards:      make([]chan *dispatchJob, workers),
+		window:      window,
+		parkTimeout: parkTimeout,
+		park:        park,
+	}
+	for index := range d.shards {
+		d.shards[index] = make(chan *dispatchJob, 64)
+		go d.run(ctx, d.shards[index])
+	}
+	return d
+}
+
+// Dispatch blocks until handle has run for the job, or ctx is done.
+func (d *dispatcher) Dispatch(ctx context.Context, key string, order dispatchOrder, handle func(ctx context.Context) error) error {
+	now := time.Now()
+	job := &dispatchJob{
+		key:     key,
+		order:   order,
+		handle:  handle,
+		done:    make(chan error, 1),
+		arrived: now,
+		readyAt: now.Add(d.window),
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	shard := d.shards[h.Sum32()%uint32(len(d.shards))]
+
+	select {
+	case shard <- job:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-job.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run owns the key queues of a shard. The head of each queue is handled in a
+// goroutine of its own, so a key that keeps failing only holds the jobs
+// queued behind it.
+func (d *dispatcher) run(ctx context.Context, in <-chan *dispatchJob) {
+	queues := make(map[string][]*dispatchJob)
+	results := make(chan dispatchResult)
+
+	for {
+		now := time.Now()
+		var next time.Time
+
+		for _, queue := range queues {
+			head := queue[0]
+			if head.running {
+				continue
+			}
+			if head.readyAt.After(now) {
+				if next.IsZero() || head.readyAt.Before(next) {
+					next = head.readyAt
+				}
+				continue
+			}
+			head.running = true
+			go func(job *dispatchJob) {
+				err := job.handle(ctx)
+				select {
+				case results <- dispatchResult{job: job, err: err}:
+				case <-ctx.Done():
+				}
+			}(head)
+		}
+
+		var wait <-chan time.Time
+		if !next.IsZero() {
+			wait = time.After(time.Until(next))
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, queue := range queues {
+				for _, job := range queue {
+					job.done <- ctx.Err()
+				}
+			}
+			return
+		case job := <-in:
+			queues[job.key] = d.insert(queues[job.key], job)
+		case result := <-results:
+			head := result.job
+			head.running = false
+			if result.err != nil && d.park(result.err) && time.Since(head.arrived) < d.parkTimeout {
+				// jobs queued while it ran may have to go first
+				head.readyAt = time.Now().Add(parkBackoff)
+				queues[head.key] = d.insert(queues[head.key][1:], head)
+				continue
+			}
+			head.done <- result.err
+			if queue := queues[head.key][1:]; len(queue) > 0 {
+				queues[head.key] = queue
+			} else {
+				delete(queues, head.key)
+			}
+		case <-wait:
+		}
+	}
+}
+
+// insert queues job behind the last job it does not have to run before,
+// never in front of a job that is already running.
+func (d *dispatcher) insert(queue []*dispatchJob, job *dispatchJob) []*dispatchJob {
+	position := len(queue)
+	for position > 0 && !queue[position-1].running && job.order.before(queue[position-1].order) {
+		position--
+	}
+	queue = append(queue, nil)
+	copy(queue[position+1:], queue[position:])
+	queue[position] = job
+
+	// a job put in front of a parked head must not wait for the backoff
+	if position == 0 && len(queue) > 1 && queue[1].readyAt.After(job.readyAt) {
+		queue[1].readyAt = job.readyAt
+	}
+	return queue
+}
@@ -0,0 +1,280 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+var errPark = errors.New("park")
+
+func isPark(err error) bool {
+	return errors.Is(err, errPark)
+}
+
+// recorder keeps the sequence of each handled job in the order they ran.
+type recorder struct {
+	mu   sync.Mutex
+	seqs []int
+}
+
+func (r *recorder) handle(seq int, err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.seqs = append(r.seqs, seq)
+		return err
+	}
+}
+
+func (r *recorder) handled() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int{}, r.seqs...)
+}
+
+// at orders a job read from topic at offset, the offset is also the sequence
+// the recorder keeps for it.
+func at(topic string, offset int, phase int) dispatchOrder {
+	return dispatchOrder{topic: topic, offset: int64(offset), phase: phase}
+}
+
+// dispatchAll dispatches one job per order from its own goroutine, delay
+// apart, and waits for all of them.
+func dispatchAll(t *testing.T, d *dispatcher, key string, delay time.Duration, r *recorder, orders ...dispatchOrder) {
+	var wg sync.WaitGroup
+	for _, order := range orders {
+		wg.Add(1)
+		go func(order dispatchOrder) {
+			defer wg.Done()
+			seq := int(order.offset)
+			if err := d.Dispatch(context.Background(), key, order, r.handle(seq, nil)); err != nil {
+				t.Errorf("Dispatch(%d) error = %v", seq, err)
+			}
+		}(order)
+		time.Sleep(delay)
+	}
+	wg.Wait()
+}
+
+// inPartition orders jobs read from one partition of answersheet-events.
+func inPartition(offsets ...int) []dispatchOrder {
+	result := make([]dispatchOrder, len(offsets))
+	for index, offset := range offsets {
+		result[index] = at("answersheet-events", offset, 1)
+	}
+	return result
+}
+
+func TestDispatcher_SameKeyAcrossTopics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := newDispatcher(ctx, 4, 50*time.Millisecond, time.Second, isPark)
+
+	// the answer and submit topics are read before the start topic, the start
+	// still runs first; another key is not held behind them
+	var user1, user2 recorder
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		dispatchAll(t, d, "1:2", 5*time.Millisecond, &user1, at("answer-test", 2, 1), at("submit-test", 3, 2), at("start-test", 1, 0))
+	}()
+	go func() {
+		defer wg.Done()
+		dispatchAll(t, d, "7:2", 5*time.Millisecond, &user2, inPartition(5, 4)...)
+	}()
+	wg.Wait()
+
+	if got, want := user1.handled(), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("handled = %v, want %v", got, want)
+	}
+	if got, want := user2.handled(), []int{4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("handled = %v, want %v", got, want)
+	}
+}
+
+func TestDispatcher_ReorderWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		delay  time.Duration
+		orders []dispatchOrder
+		want   []int
+	}{
+		{name: "within window", delay: 5 * time.Millisecond, orders: inPartition(3, 1, 2), want: []int{1, 2, 3}},
+		{name: "after window", delay: 80 * time.Millisecond, orders: inPartition(3, 1, 2), want: []int{3, 1, 2}},
+		{
+			name:   "offsets of other topics",
+			delay:  5 * time.Millisecond,
+			orders: []dispatchOrder{at("answer-test", 3, 1), at("answer-test", 1, 1), at("submit-test", 2, 2)},
+			want:   []int{1, 3, 2},
+		},
+		{
+			name:   "same phase keeps arrival order",
+			delay:  5 * time.Millisecond,
+			orders: []dispatchOrder{at("answer-test", 3, 1), at("answersheet-events", 1, 1)},
+			want:   []int{3, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			d := newDispatcher(ctx, 1, 40*time.Millisecond, time.Second, isPark)
+
+			var r recorder
+			dispatchAll(t, d, "1:2", tt.delay, &r, tt.orders...)
+			if got := r.handled(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("handled = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatcher_Park(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		wantErr  error
+	}{
+		{name: "retried until it succeeds", failures: 1},
+		{name: "fails after park timeout", failures: 100, wantErr: errPark},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			parkTimeout := 3 * parkBackoff / 2
+			d := newDispatcher(ctx, 1, 0, parkTimeout, isPark)
+
+			attempts := 0
+			start := time.Now()
+			err := d.Dispatch(context.Background(), "1:2", at("answersheet-events", 1, 1), func(ctx context.Context) error {
+				attempts++
+				if attempts <= tt.failures {
+					return errPark
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Dispatch() error = %v, want %v", err, tt.wantErr)
+			}
+			if attempts < 2 {
+				t.Errorf("attempts = %d, want a retry", attempts)
+			}
+			if tt.wantErr != nil && time.Since(start) < parkTimeout {
+				t.Errorf("failed after %v, before the park timeout %v", time.Since(start), parkTimeout)
+			}
+		})
+	}
+}
+
+func TestDispatcher_JobBeforeParkedHead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := newDispatcher(ctx, 1, 10*time.Millisecond, time.Second, isPark)
+
+	// seq 2 parks until seq 1 has run
+	var r recorder
+	startDone := make(chan struct{})
+	parked := make(chan struct{}, 1)
+	second := make(chan error, 1)
+	go func() {
+		second <- d.Dispatch(context.Background(), "1:2", at("answersheet-events", 2, 1), func(ctx context.Context) error {
+			select {
+			case <-startDone:
+				return r.handle(2, nil)(ctx)
+			default:
+				select {
+				case parked <- struct{}{}:
+				default:
+				}
+				return errPark
+			}
+		})
+	}()
+	<-parked
+
+	begin := time.Now()
+	err := d.Dispatch(context.Background(), "1:2", at("answersheet-events", 1, 1), func(ctx context.Context) error {
+		close(startDone)
+		return r.handle(1, nil)(ctx)
+	})
+	if err != nil {
+		t.Fatalf("Dispatch(1) error = %v", err)
+	}
+	if err := <-second; err != nil {
+		t.Fatalf("Dispatch(2) error = %v", err)
+	}
+
+	if elapsed := time.Since(begin); elapsed >= parkBackoff {
+		t.Errorf("parked head ran after %v, want it right behind the new job", elapsed)
+	}
+	if got, want := r.handled(), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("handled = %v, want %v", got, want)
+	}
+}
+
+func TestDispatcher_FailingKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := newDispatcher(ctx, 1, 0, time.Second, isPark)
+
+	// both keys share the only shard, the one still retrying holds only its own
+	release := make(chan struct{})
+	failing := make(chan error, 1)
+	go func() {
+		failing <- d.Dispatch(context.Background(), "1:2", at("answersheet-events", 1, 1), func(ctx context.Context) error {
+			<-release
+			return nil
+		})
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	other := make(chan error, 1)
+	go func() {
+		other <- d.Dispatch(context.Background(), "7:2", at("answersheet-events", 1, 1), func(ctx context.Context) error {
+			return nil
+		})
+	}()
+	select {
+	case err := <-other:
+		if err != nil {
+			t.Errorf("Dispatch() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("another key waited for the failing one")
+	}
+
+	close(release)
+	if err := <-failing; err != nil {
+		t.Errorf("Dispatch() error = %v", err)
+	}
+}
+
+func TestDispatcher_Shutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	d := newDispatcher(ctx, 1, time.Hour, time.Hour, isPark)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- d.Dispatch(context.Background(), "1:2", at("answersheet-events", 1, 1), func(ctx context.Context) error {
+			t.Error("job held in the reorder window was handled")
+			return nil
+		})
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Dispatch() error = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Dispatch() did not return after shutdown")
+	}
+}
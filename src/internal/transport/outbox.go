@@ -0,0 +1,54 @@
+package transport
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"picket-answersheet-service/src/config"
+	"time"
+)
+
+type IOutboxUsecase interface {
+	Relay(ctx context.Context, limit int) (int, error)
+}
+
+type OutboxTransport struct {
+	config        config.IConfig
+	outboxUsecase IOutboxUsecase
+}
+
+func NewOutboxTransport(ctx context.Context, outboxUsecase IOutboxUsecase, config config.IConfig) *OutboxTransport {
+	t := &OutboxTransport{
+		outboxUsecase: outboxUsecase,
+		config:        config,
+	}
+
+	go t.Relay(ctx)
+
+	return t
+}
+
+func (t *OutboxTransport) Relay(ctx context.Context) {
+	limit := t.config.GetOutboxBatchSize()
+	ticker := time.NewTicker(t.config.GetOutboxPollInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			count, err := t.outboxUsecase.Relay(ctx, limit)
+			if err != nil {
+				log.Error().Err(err).Msg("relay outbox")
+				break
+			}
+			// a full batch means more rows are probably waiting
+			if count < limit {
+				break
+			}
+		}
+	}
+}
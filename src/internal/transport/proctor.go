@@ -0,0 +1,141 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"picket-answersheet-service/src/internal/usecase"
+	"picket-answersheet-service/src/middlewares"
+	answersheetpb "picket-answersheet-service/src/pb/answer_sheet"
+	"time"
+)
+
+func (t *answersheetTransport) ListActiveSessions(ctx context.Context, request *answersheetpb.ListActiveSessionsRequest) (*answersheetpb.ListActiveSessionsResponse, error) {
+	sessions, err := t.watch.ListActiveSessions(ctx, int(request.TestId))
+	if err != nil {
+		log.Error().Err(err).Int64("test_id", request.TestId).Msg("list active sessions")
+		return nil, status.Error(codes.Internal, "server has error")
+	}
+	now := time.Now()
+	participants := make([]*answersheetpb.Participant, len(sessions))
+	for index := range sessions {
+		participants[index] = newParticipant(&sessions[index], now)
+	}
+	return &answersheetpb.ListActiveSessionsResponse{Participants: participants}, nil
+}
+
+func (t *answersheetTransport) WatchTest(request *answersheetpb.WatchTestRequest, stream answersheetpb.AnswerSheetService_WatchTestServer) error {
+	ctx, cancel := t.streamContext(stream.Context())
+	defer cancel()
+
+	err := t.watch.WatchTest(ctx, int(request.TestId), func(update entities.SessionUpdate) error {
+		result := &answersheetpb.TestUpdate{
+			Type:        update.Type,
+			Participant: newParticipant(&update.Session, update.At),
+			OccurredAt:  timestamppb.New(update.At),
+		}
+		if update.Answer != nil {
+			result.Answer = newAnswer(*update.Answer)
+		}
+		return stream.Send(result)
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("test_id", request.TestId).Msg("watch test")
+		return status.Error(codes.Internal, "server has error")
+	}
+	return nil
+}
+
+func (t *answersheetTransport) ExtendTime(ctx context.Context, request *answersheetpb.ProctorActionRequest) (*answersheetpb.ProctorActionResponse, error) {
+	return proctorAction(ctx, request, t.usecase.ExtendTime)
+}
+
+func (t *answersheetTransport) ForceSubmit(ctx context.Context, request *answersheetpb.ProctorActionRequest) (*answersheetpb.ProctorActionResponse, error) {
+	return proctorAction(ctx, request, t.usecase.ForceSubmit)
+}
+
+func (t *answersheetTransport) VoidSession(ctx context.Context, request *answersheetpb.ProctorActionRequest) (*answersheetpb.ProctorActionResponse, error) {
+	return proctorAction(ctx, request, t.usecase.VoidSession)
+}
+
+func (t *answersheetTransport) PauseTest(ctx context.Context, request *answersheetpb.ProctorActionRequest) (*answersheetpb.ProctorActionResponse, error) {
+	return proctorAction(ctx, request, t.usecase.ProctorPause)
+}
+
+func (t *answersheetTransport) ResumeTest(ctx context.Context, request *answersheetpb.ProctorActionRequest) (*answersheetpb.ProctorActionResponse, error) {
+	return proctorAction(ctx, request, t.usecase.ProctorResume)
+}
+
+// proctorAction applies action for the proctor named by the token of the call,
+// who must be allowed on the test; the proctor_id of the request is only
+// checked against it.
+func proctorAction(ctx context.Context, request *answersheetpb.ProctorActionRequest, action func(ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error)) (*answersheetpb.ProctorActionResponse, error) {
+	identity := middlewares.IdentityFrom(ctx)
+	if identity == nil {
+		return nil, status.Error(codes.Unauthenticated, "proctor actions need a bearer token")
+	}
+	if !identity.CanProctor(int(request.TestId)) {
+		return nil, status.Error(codes.PermissionDenied, "not a proctor of the test")
+	}
+	if request.ProctorId != 0 && int(request.ProctorId) != identity.UserId {
+		return nil, status.Error(codes.PermissionDenied, "proctor_id does not match the token")
+	}
+	event, session, err := action(ctx, dto.ProctorActionInput{
+		ProctorId:      identity.UserId,
+		UserId:         int(request.UserId),
+		TestId:         int(request.TestId),
+		Minutes:        int(request.Minutes),
+		Reason:         request.Reason,
+		IdempotencyKey: request.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, proctorError(err)
+	}
+	return &answersheetpb.ProctorActionResponse{
+		EventId:     event.Id.Hex(),
+		Participant: newParticipant(session, time.Now()),
+	}, nil
+}
+
+func proctorError(err error) error {
+	var validationErr *usecase.ValidationError
+	var transitionErr *entities.TransitionError
+	switch {
+	case errors.As(err, &validationErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, usecase.ErrSessionNotStarted):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &transitionErr), errors.Is(err, usecase.ErrNoDeadline):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, entities.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		log.Error().Err(err).Msg("proctor action")
+		return status.Error(codes.Internal, "server has error")
+	}
+}
+
+func newParticipant(session *entities.Session, now time.Time) *answersheetpb.Participant {
+	result := &answersheetpb.Participant{
+		UserId:         int64(session.UserId),
+		Session:        session.SessionId,
+		Attempt:        int32(session.Attempt),
+		State:          session.StateAt(now),
+		Answered:       int32(len(session.Answers)),
+		AntiCheatFlags: session.AntiCheatFlags(),
+		Version:        int64(session.Version),
+	}
+	if session.StartedAt != nil {
+		result.StartedAt = timestamppb.New(*session.StartedAt)
+	}
+	if remaining, ok := session.Remaining(now); ok {
+		result.Deadline = timestamppb.New(*session.Deadline)
+		result.RemainingSeconds = int64(remaining / time.Second)
+	}
+	return result
+}
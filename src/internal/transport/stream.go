@@ -0,0 +1,161 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"github.com/avast/retry-go"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"picket-answersheet-service/src/internal/codec"
+	"picket-answersheet-service/src/internal/entities"
+	"picket-answersheet-service/src/internal/usecase"
+	"picket-answersheet-service/src/middlewares"
+	answersheetpb "picket-answersheet-service/src/pb/answer_sheet"
+)
+
+// Error codes of a CommandAck.
+const (
+	ERROR_INVALID_ARGUMENT    = "INVALID_ARGUMENT"
+	ERROR_SESSION_NOT_STARTED = "SESSION_NOT_STARTED"
+	ERROR_SESSION_IN_PROGRESS = "SESSION_IN_PROGRESS"
+	ERROR_ALREADY_SUBMITTED   = "ALREADY_SUBMITTED"
+	ERROR_ANSWER_AFTER_SUBMIT = "ANSWER_AFTER_SUBMIT"
+	ERROR_INVALID_STATE       = "INVALID_STATE"
+	ERROR_CONFLICT            = "CONFLICT"
+	ERROR_PERMISSION_DENIED   = "PERMISSION_DENIED"
+	ERROR_INTERNAL            = "INTERNAL"
+)
+
+// streamContext is the context of a stream, also cancelled when the server
+// stops so the stream ends and GracefulStop can return.
+func (t *answersheetTransport) streamContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	go func() {
+		select {
+		case <-t.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// AnswerStream handles the commands of an authenticated student in the order
+// they arrive and acks each of them. Commands are dispatched on the same user
+// and test key as the Kafka events, without job notifications.
+func (t *answersheetTransport) AnswerStream(stream answersheetpb.AnswerSheetService_AnswerStreamServer) error {
+	identity := middlewares.IdentityFrom(stream.Context())
+	if identity == nil {
+		return status.Error(codes.Unauthenticated, "answer stream needs a bearer token")
+	}
+	ctx, cancel := t.streamContext(stream.Context())
+	defer cancel()
+
+	// the commands of a stream keep their order like the messages of a partition
+	order := dispatchOrder{topic: "answer-stream/" + uuid.NewString()}
+	for ; ; order.offset++ {
+		command, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(t.handleCommand(ctx, identity, order, command)); err != nil {
+			return err
+		}
+	}
+}
+
+func (t *answersheetTransport) handleCommand(ctx context.Context, identity *middlewares.Identity, order dispatchOrder, command *answersheetpb.AnswerCommand) *answersheetpb.CommandAck {
+	ack := &answersheetpb.CommandAck{CommandId: command.CommandId}
+	if err := validateCommand(command); err != nil {
+		return withError(ack, err)
+	}
+	envelope, err := codec.FromProto(command.Event)
+	if err != nil {
+		return withError(ack, err)
+	}
+	if envelope.UserId != 0 && envelope.UserId != identity.UserId {
+		ack.ErrorCode = ERROR_PERMISSION_DENIED
+		ack.ErrorMessage = "user_id does not match the token"
+		return ack
+	}
+	envelope.UserId = identity.UserId
+	envelope.JobId = 0
+
+	var event *entities.Event
+	order.phase = eventPhase(envelope.Type)
+	err = t.dispatcher.Dispatch(ctx, envelope.Key(), order, func(ctx context.Context) error {
+		return retry.Do(func() error {
+			var err error
+			event, err = t.process(ctx, envelope)
+			return err
+		}, retry.Attempts(3), retry.Context(ctx), retry.RetryIf(isConflict), retry.LastErrorOnly(true))
+	})
+	if err != nil {
+		return withError(ack, err)
+	}
+
+	ack.Ok = true
+	ack.EventId = event.Id.Hex()
+	ack.Session = event.Session
+	return ack
+}
+
+func validateCommand(command *answersheetpb.AnswerCommand) error {
+	if command.Event == nil {
+		return &usecase.ValidationError{Fields: []usecase.FieldError{{Field: "event", Message: "failed required"}}}
+	}
+	switch command.Event.Type {
+	case entities.START, entities.END:
+	case entities.ANSWER, entities.FLAG, entities.UNFLAG:
+		if command.Event.Answer == nil {
+			return &usecase.ValidationError{Fields: []usecase.FieldError{{Field: "event.answer", Message: "failed required"}}}
+		}
+	default:
+		return &usecase.ValidationError{Fields: []usecase.FieldError{{Field: "event.type", Message: "must be one of START, ANSWER, FLAG, UNFLAG, END"}}}
+	}
+	return nil
+}
+
+func isConflict(err error) bool {
+	return errors.Is(err, entities.ErrVersionConflict)
+}
+
+// withError fills the error of the ack. Internal errors are logged and not
+// shown to the client.
+func withError(ack *answersheetpb.CommandAck, err error) *answersheetpb.CommandAck {
+	var validationErr *usecase.ValidationError
+	var afterSubmitErr *usecase.AnswerAfterSubmitError
+	var transitionErr *entities.TransitionError
+
+	ack.ErrorMessage = err.Error()
+	switch {
+	case errors.As(err, &validationErr):
+		ack.ErrorCode = ERROR_INVALID_ARGUMENT
+		for _, item := range validationErr.Fields {
+			ack.FieldErrors = append(ack.FieldErrors, &answersheetpb.FieldError{Field: item.Field, Message: item.Message})
+		}
+	case errors.As(err, &afterSubmitErr):
+		ack.ErrorCode = ERROR_ANSWER_AFTER_SUBMIT
+	case errors.As(err, &transitionErr):
+		ack.ErrorCode = ERROR_INVALID_STATE
+	case errors.Is(err, usecase.ErrSessionNotStarted):
+		ack.ErrorCode = ERROR_SESSION_NOT_STARTED
+	case errors.Is(err, usecase.ErrSessionInProgress):
+		ack.ErrorCode = ERROR_SESSION_IN_PROGRESS
+	case errors.Is(err, usecase.ErrUserSubmitted):
+		ack.ErrorCode = ERROR_ALREADY_SUBMITTED
+	case errors.Is(err, entities.ErrVersionConflict):
+		ack.ErrorCode = ERROR_CONFLICT
+	default:
+		log.Error().Err(err).Str("command_id", ack.CommandId).Msg("answer stream command")
+		ack.ErrorCode = ERROR_INTERNAL
+		ack.ErrorMessage = "server has error"
+	}
+	return ack
+}
@@ -0,0 +1,55 @@
+package transport
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"picket-answersheet-service/src/config"
+	"time"
+)
+
+type ISweeperUsecase interface {
+	SubmitExpired(ctx context.Context, limit int) (int, error)
+}
+
+// SweeperTransport submits the sessions whose deadline has passed without an
+// END from the client.
+type SweeperTransport struct {
+	config  config.IConfig
+	usecase ISweeperUsecase
+}
+
+func NewSweeperTransport(ctx context.Context, usecase ISweeperUsecase, config config.IConfig) *SweeperTransport {
+	t := &SweeperTransport{
+		usecase: usecase,
+		config:  config,
+	}
+
+	go t.Sweep(ctx)
+
+	return t
+}
+
+func (t *SweeperTransport) Sweep(ctx context.Context) {
+	limit := t.config.GetSweeperBatchSize()
+	ticker := time.NewTicker(t.config.GetSweeperInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			count, err := t.usecase.SubmitExpired(ctx, limit)
+			if err != nil {
+				log.Error().Err(err).Msg("submit expired sessions")
+				break
+			}
+			if count < limit {
+				break
+			}
+		}
+	}
+}
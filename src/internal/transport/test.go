@@ -6,26 +6,26 @@ import (
 	"encoding/json"
 	"github.com/avast/retry-go"
 	"github.com/rs/zerolog/log"
-	"github.com/segmentio/kafka-go"
 	"picket-answersheet-service/src/config"
 	"picket-answersheet-service/src/internal/entities"
-	"strings"
 )
 
 type TestTransport struct {
 	config      config.IConfig
 	testUsecase ITestUsecase
+	broker      IBroker
 }
 
 type ITestUsecase interface {
 	SyncTest(ctx context.Context, test *entities.Test) error
 }
 
-func NewTestTransport(ctx context.Context, testUsecase ITestUsecase, config config.IConfig) *TestTransport {
+func NewTestTransport(ctx context.Context, testUsecase ITestUsecase, config config.IConfig, broker IBroker) *TestTransport {
 
 	t := &TestTransport{
 		testUsecase: testUsecase,
 		config:      config,
+		broker:      broker,
 	}
 
 	go t.SyncTest(ctx)
@@ -34,34 +34,36 @@ func NewTestTransport(ctx context.Context, testUsecase ITestUsecase, config conf
 }
 
 func (t *TestTransport) SyncTest(ctx context.Context) {
-	r := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: strings.Split(t.config.GetKafkaBroker(), ","),
-		Topic:   "sync-test",
-		GroupID: "sync-test-1",
-		//Logger:  kafka.LoggerFunc(log.Debug().Msgf),
-	})
+	r := t.broker.Reader("sync-test", "sync-test-1")
 	defer func() {
 		if err := r.Close(); err != nil {
 			log.Error().Err(err).Send()
 		}
 	}()
 	for {
-		m, err := r.ReadMessage(ctx)
+		m, err := r.FetchMessage(ctx)
 		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
 			log.Ctx(ctx).Error().Err(err).Send()
 			continue
 		}
 		var test entities.Test
 		if err := json.NewDecoder(bytes.NewBuffer(m.Value)).Decode(&test); err != nil {
 			log.Ctx(ctx).Error().Err(err).Send()
+			r.CommitMessages(ctx, m)
 			continue
 		}
 		log.Info().Interface("test", test).Str("topic", "sync-test").Send()
 		err = retry.Do(func() error {
 			return t.testUsecase.SyncTest(ctx, &test)
-		})
+		}, retry.Context(ctx))
 		if err != nil {
 			log.Error().Err(err).Send()
 		}
+		if err := r.CommitMessages(ctx, m); err != nil {
+			log.Error().Err(err).Send()
+		}
 	}
 }
@@ -0,0 +1,145 @@
+package usecase
+
+//go:generate mockgen -source=accommodation.go -destination=../mocks/accommodation.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+type IAccommodationRepository interface {
+	Save(ctx context.Context, accommodation *entities.Accommodation) error
+	FindByTestIdAndUserId(ctx context.Context, testId int, userId int) (*entities.Accommodation, error)
+}
+
+type accommodationUsecase struct {
+	repository  IAccommodationRepository
+	sessions    IAnswersheetRepository
+	testUsecase ITestUsecase
+	hub         IHub
+}
+
+func NewAccommodationUsecase(repository IAccommodationRepository, sessions IAnswersheetRepository, testUsecase ITestUsecase, hub IHub) *accommodationUsecase {
+	return &accommodationUsecase{repository: repository, sessions: sessions, testUsecase: testUsecase, hub: hub}
+}
+
+// accommodateAttempts bounds how often the running session is read again when
+// another event lands on it while its deadline is moved.
+const accommodateAttempts = 3
+
+// SetAccommodation stores the accommodation of the user at the test, replacing
+// the previous one. The sessions started afterwards get it from their START,
+// the running session has its deadline moved by an EXTEND.
+func (u *accommodationUsecase) SetAccommodation(ctx context.Context, input dto.AccommodationInput) (*entities.Accommodation, error) {
+	if err := validateStruct(input); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	accommodation := entities.Accommodation{
+		TestId:       input.TestId,
+		UserId:       input.UserId,
+		ExtraMinutes: input.ExtraMinutes,
+		Multiplier:   input.Multiplier,
+		UpdatedBy:    input.UpdatedBy,
+		UpdatedAt:    &now,
+	}
+	if err := u.repository.Save(ctx, &accommodation); err != nil {
+		log.Error().Err(err).Send()
+		return nil, err
+	}
+	for try := 1; ; try++ {
+		err := u.accommodate(ctx, &accommodation)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, entities.ErrVersionConflict) || try == accommodateAttempts {
+			log.Error().Err(err).Int("test_id", input.TestId).Int("user_id", input.UserId).Msg("accommodate session")
+			return nil, err
+		}
+	}
+	log.Info().Int("test_id", input.TestId).Int("user_id", input.UserId).Int("extra_minutes", input.ExtraMinutes).
+		Float64("multiplier", input.Multiplier).Int("updated_by", input.UpdatedBy).Msg("accommodation set")
+	return &accommodation, nil
+}
+
+// accommodate extends the running session of the user at the test by the
+// difference between accommodation and what the session was already given, so
+// the deadline is the one a session started now would get.
+func (u *accommodationUsecase) accommodate(ctx context.Context, accommodation *entities.Accommodation) error {
+	session, err := u.sessions.FindLatestSession(ctx, accommodation.UserId, accommodation.TestId)
+	if errors.Is(err, entities.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if !session.IsActive(now) || session.Deadline == nil {
+		return nil
+	}
+	test, err := u.testUsecase.GetByTestIdAndVersion(ctx, session.TestId, session.TestVersion)
+	if err != nil {
+		return err
+	}
+	events, err := u.sessions.FindBySession(ctx, session.SessionId)
+	if err != nil {
+		return err
+	}
+	extension := accommodation.Extra(test.TimeAllowed()) - accommodated(events)
+	if extension == 0 {
+		return nil
+	}
+
+	e := entities.Event{
+		Id:        primitive.NewObjectID(),
+		UserId:    session.UserId,
+		TestId:    session.TestId,
+		Event:     entities.EXTEND,
+		Session:   session.SessionId,
+		Reason:    entities.REASON_ACCOMMODATION,
+		ActorId:   accommodation.UpdatedBy,
+		Extension: extension,
+	}
+	e.Stamp(now, nil, 0)
+	expected := session.Version
+	if err := session.Apply(&e); err != nil {
+		return err
+	}
+	if err := u.sessions.Create(ctx, &e, session, expected, nil); err != nil {
+		return err
+	}
+	u.hub.Publish(entities.SessionUpdate{Type: entities.UPDATE_EXTEND, Session: *session, At: now})
+	return nil
+}
+
+// accommodated sums the time the events of a session gave it for
+// accommodations: the extension of its START and of the EXTENDs written by
+// accommodate.
+func accommodated(events []entities.Event) time.Duration {
+	var result time.Duration
+	for _, event := range events {
+		if event.Event == entities.START || (event.Event == entities.EXTEND && event.Reason == entities.REASON_ACCOMMODATION) {
+			result += event.Extension
+		}
+	}
+	return result
+}
+
+// GetAccommodation returns the accommodation of the user at the test, nil when
+// the user has none.
+func (u *accommodationUsecase) GetAccommodation(ctx context.Context, testId int, userId int) (*entities.Accommodation, error) {
+	accommodation, err := u.repository.FindByTestIdAndUserId(ctx, testId, userId)
+	if errors.Is(err, entities.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error().Err(err).Send()
+		return nil, err
+	}
+	return accommodation, nil
+}
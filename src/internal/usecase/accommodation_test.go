@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"picket-answersheet-service/src/internal/mocks"
+	"testing"
+	"time"
+)
+
+type accommodationFixture struct {
+	repository  *mocks.MockIAccommodationRepository
+	sessions    *mocks.MockIAnswersheetRepository
+	testUsecase *mocks.MockITestUsecase
+	usecase     *accommodationUsecase
+}
+
+func newAccommodationFixture(t *testing.T) *accommodationFixture {
+	ctrl := gomock.NewController(t)
+	f := &accommodationFixture{
+		repository:  mocks.NewMockIAccommodationRepository(ctrl),
+		sessions:    mocks.NewMockIAnswersheetRepository(ctrl),
+		testUsecase: mocks.NewMockITestUsecase(ctrl),
+	}
+	hub := mocks.NewMockIHub(ctrl)
+	hub.EXPECT().Publish(gomock.Any()).AnyTimes()
+	f.usecase = NewAccommodationUsecase(f.repository, f.sessions, f.testUsecase, hub)
+	return f
+}
+
+// running makes the latest session of user 1 at test 2 one started with a
+// START extension of startExtension, at a version of the test of 60 minutes.
+func (f *accommodationFixture) running(session *entities.Session, startExtension time.Duration) {
+	f.sessions.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(session, nil)
+	f.testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, session.TestVersion).Return(&entities.Test{TestId: 2, TimeToDo: 60}, nil)
+	f.sessions.EXPECT().FindBySession(gomock.Any(), session.SessionId).Return([]entities.Event{
+		{UserId: 1, TestId: 2, Event: entities.START, Session: session.SessionId, Extension: startExtension},
+		{UserId: 1, TestId: 2, Event: entities.EXTEND, Session: session.SessionId, ActorId: 100, Extension: 5 * time.Minute},
+	}, nil)
+}
+
+func TestAccommodationUsecase_SetAccommodation(t *testing.T) {
+	var validationErr *ValidationError
+	saved := func(f *accommodationFixture) {
+		f.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	}
+
+	tests := []struct {
+		name    string
+		input   dto.AccommodationInput
+		setup   func(f *accommodationFixture)
+		wantErr error
+		wantAs  interface{}
+	}{
+		{
+			name:  "stored",
+			input: dto.AccommodationInput{TestId: 2, UserId: 1, ExtraMinutes: 15, Multiplier: 1.5, UpdatedBy: 9},
+			setup: func(f *accommodationFixture) {
+				f.repository.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, accommodation *entities.Accommodation) error {
+					if accommodation.TestId != 2 || accommodation.UserId != 1 || accommodation.ExtraMinutes != 15 || accommodation.UpdatedAt == nil {
+						t.Errorf("unexpected accommodation %+v", accommodation)
+					}
+					return nil
+				})
+				f.sessions.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(nil, entities.ErrNotFound)
+			},
+		},
+		{
+			name:  "running session is extended by the difference",
+			input: dto.AccommodationInput{TestId: 2, UserId: 1, Multiplier: 1.5, UpdatedBy: 9},
+			setup: func(f *accommodationFixture) {
+				saved(f)
+				session := inProgress()
+				deadline := *session.Deadline
+				f.running(session, 10*time.Minute)
+				f.sessions.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 1, nil).DoAndReturn(func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+					if event.Event != entities.EXTEND || event.Reason != entities.REASON_ACCOMMODATION || event.ActorId != 9 || event.Extension != 20*time.Minute {
+						t.Errorf("unexpected event %+v", event)
+					}
+					if !session.Deadline.Equal(deadline.Add(20*time.Minute)) || session.Version != 2 {
+						t.Errorf("deadline %v version %d, want %v", session.Deadline, session.Version, deadline.Add(20*time.Minute))
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name:  "smaller accommodation brings the deadline forward",
+			input: dto.AccommodationInput{TestId: 2, UserId: 1, ExtraMinutes: 5},
+			setup: func(f *accommodationFixture) {
+				saved(f)
+				f.running(inProgress(), 10*time.Minute)
+				f.sessions.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 1, nil).DoAndReturn(func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+					if event.Extension != -5*time.Minute {
+						t.Errorf("extension %v, want -5m", event.Extension)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name:  "session already accommodated",
+			input: dto.AccommodationInput{TestId: 2, UserId: 1, ExtraMinutes: 10},
+			setup: func(f *accommodationFixture) {
+				saved(f)
+				f.running(pausedFor(time.Minute), 10*time.Minute)
+			},
+		},
+		{
+			name:  "submitted session is left alone",
+			input: dto.AccommodationInput{TestId: 2, UserId: 1, ExtraMinutes: 10},
+			setup: func(f *accommodationFixture) {
+				saved(f)
+				f.sessions.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(submittedAt(time.Now()), nil)
+			},
+		},
+		{
+			name:  "session written meanwhile is read again",
+			input: dto.AccommodationInput{TestId: 2, UserId: 1, ExtraMinutes: 20},
+			setup: func(f *accommodationFixture) {
+				saved(f)
+				gomock.InOrder(
+					f.sessions.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 1, nil).Return(entities.ErrVersionConflict),
+					f.sessions.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 1, nil).Return(nil),
+				)
+				f.running(inProgress(), 0)
+				f.running(inProgress(), 0)
+			},
+		},
+		{
+			name:  "session error",
+			input: dto.AccommodationInput{TestId: 2, UserId: 1, ExtraMinutes: 20},
+			setup: func(f *accommodationFixture) {
+				saved(f)
+				f.sessions.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(nil, errStorage)
+			},
+			wantErr: errStorage,
+		},
+		{
+			name:   "multiplier below 1",
+			input:  dto.AccommodationInput{TestId: 2, UserId: 1, Multiplier: 0.5},
+			setup:  func(f *accommodationFixture) {},
+			wantAs: &validationErr,
+		},
+		{
+			name:   "user is required",
+			input:  dto.AccommodationInput{TestId: 2, ExtraMinutes: 10},
+			setup:  func(f *accommodationFixture) {},
+			wantAs: &validationErr,
+		},
+		{
+			name:  "save error",
+			input: dto.AccommodationInput{TestId: 2, UserId: 1, ExtraMinutes: 15},
+			setup: func(f *accommodationFixture) {
+				f.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(errStorage)
+			},
+			wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAccommodationFixture(t)
+			tt.setup(f)
+			_, err := f.usecase.SetAccommodation(context.Background(), tt.input)
+			checkErr(t, err, tt.wantErr, tt.wantAs)
+		})
+	}
+}
+
+func TestAccommodationUsecase_GetAccommodation(t *testing.T) {
+	f := newAccommodationFixture(t)
+	repository, u := f.repository, f.usecase
+
+	repository.EXPECT().FindByTestIdAndUserId(gomock.Any(), 2, 1).Return(nil, entities.ErrNotFound)
+	if got, err := u.GetAccommodation(context.Background(), 2, 1); got != nil || err != nil {
+		t.Fatalf("GetAccommodation() = %v, %v, want none", got, err)
+	}
+
+	repository.EXPECT().FindByTestIdAndUserId(gomock.Any(), 2, 1).Return(nil, errStorage)
+	if _, err := u.GetAccommodation(context.Background(), 2, 1); !errors.Is(err, errStorage) {
+		t.Fatalf("GetAccommodation() error = %v, want %v", err, errStorage)
+	}
+}
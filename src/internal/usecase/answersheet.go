@@ -1,316 +1,498 @@
 package usecase
 
+//go:generate mockgen -source=answersheet.go -destination=../mocks/answersheet.go -package=mocks
+
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
-	"github.com/go-playground/validator/v10"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"github.com/segmentio/kafka-go"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"picket-answersheet-service/src/config"
 	"picket-answersheet-service/src/internal/dto"
 	"picket-answersheet-service/src/internal/entities"
-	"strings"
+	"strconv"
 	"time"
 )
 
 type IAnswersheetRepository interface {
-	Create(ctx context.Context, event *entities.Event) error
-	FindByStatusEnd(ctx context.Context, userId int, testId int) (*entities.Event, error)
-	GetLatestEvent(ctx context.Context, userId int, testId int) ([]entities.Event, error)
-	GetLatestStartEvent(ctx context.Context, userId int, testId int) (*entities.Event, error)
-	GetLatestEventWithLimit(ctx context.Context, userId int, testId int, limit int) ([]entities.Event, error)
+	Create(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error
+	FindByIdempotencyKey(ctx context.Context, key string) (*entities.Event, error)
+	FindBySession(ctx context.Context, sessionId string) ([]entities.Event, error)
+	FindLatestSession(ctx context.Context, userId int, testId int) (*entities.Session, error)
+	FindSession(ctx context.Context, sessionId string) (*entities.Session, error)
+	SaveSession(ctx context.Context, session *entities.Session, expectedVersion int) error
+	ListSessionIds(ctx context.Context) ([]string, error)
+	FindExpiredSessions(ctx context.Context, now time.Time, limit int) ([]entities.Session, error)
+	FindActiveSessions(ctx context.Context, testId int) ([]entities.Session, error)
 	FindAnswerByUserIdAndTestId(ctx context.Context, userId int, testId int, sessionId string) ([]entities.Event, error)
 }
 
+type IPublisher interface {
+	Publish(ctx context.Context, topic string, messages ...kafka.Message) error
+}
+
 type answersheetUsecase struct {
 	repository  IAnswersheetRepository
 	config      config.IConfig
 	testUsecase ITestUsecase
+	publisher   IPublisher
+	outbox      IOutboxRepository
+	hub         IHub
+
+	accommodations IAccommodationUsecase
 }
 
 type ITestUsecase interface {
 	GetByTestId(ctx context.Context, testId int) (*entities.Test, error)
+	GetByTestIdAndVersion(ctx context.Context, testId int, version int) (*entities.Test, error)
+}
+
+type IAccommodationUsecase interface {
+	GetAccommodation(ctx context.Context, testId int, userId int) (*entities.Accommodation, error)
 }
 
-func NewAnswersheetUsecase(repository IAnswersheetRepository, iConfig config.IConfig, testUsecase ITestUsecase) *answersheetUsecase {
-	return &answersheetUsecase{repository: repository, config: iConfig, testUsecase: testUsecase}
+func NewAnswersheetUsecase(repository IAnswersheetRepository, iConfig config.IConfig, testUsecase ITestUsecase, publisher IPublisher, outbox IOutboxRepository, hub IHub, accommodations IAccommodationUsecase) *answersheetUsecase {
+	return &answersheetUsecase{repository: repository, config: iConfig, testUsecase: testUsecase, publisher: publisher, outbox: outbox, hub: hub, accommodations: accommodations}
 }
 
 var tracer = otel.Tracer("usecase")
 var ErrUserSubmitted = errors.New("user submitted")
 
-func (u *answersheetUsecase) StartTest(ctx context.Context, input dto.StartTestInput) error {
+var ErrSessionInProgress = errors.New("session in progress")
 
-	session := uuid.New()
-	e := entities.Event{
-		UserId:    input.Payload.UserId,
-		TestId:    input.Payload.TestId,
-		CreatedAt: input.Payload.CreatedAt,
-		UpdatedAt: input.Payload.UpdatedAt,
-		Event:     entities.START,
-		Id:        primitive.NewObjectID(),
-		Session:   session.String(),
-	}
-	err := u.repository.Create(ctx, &e)
-	if err != nil {
-		log.Error().Err(err).Send()
-		return err
-	}
+// ErrSessionNotStarted means the event refers to a session whose START has not
+// been stored yet; the caller may retry once the START has been processed.
+var ErrSessionNotStarted = errors.New("session not started")
 
-	log.Info().Int("user_id", e.UserId).Int("test_id", e.TestId).Msg("user start test")
+// AnswerAfterSubmitError is returned for every ANSWER to a session that is
+// already submitted, whatever its time. It is never stored.
+type AnswerAfterSubmitError struct {
+	SessionId  string
+	QuestionId int
+}
 
-	return nil
+func (e *AnswerAfterSubmitError) Error() string {
+	return fmt.Sprintf("answer to question %d after session %s was submitted", e.QuestionId, e.SessionId)
+}
 
+// findDuplicate returns the event already stored with the same idempotency
+// key, if any; its notification is then already in the outbox.
+func (u *answersheetUsecase) findDuplicate(ctx context.Context, key string) (*entities.Event, error) {
+	if key == "" {
+		return nil, nil
+	}
+	event, err := u.repository.FindByIdempotencyKey(ctx, key)
+	if errors.Is(err, entities.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error().Err(err).Send()
+		return nil, err
+	}
+	log.Info().Str("idempotency_key", key).Msg("duplicate event")
+	return event, nil
 }
 
-func (u *answersheetUsecase) CheckUserDoingTest(ctx context.Context, userId int, testId int) (bool, error) {
-	ctx, span := tracer.Start(ctx, "get lastest event ", trace.WithAttributes(attribute.Int("userId", userId), attribute.Int("testId", testId)))
-	defer span.End()
-	list, err := u.repository.GetLatestEvent(ctx, userId, testId)
+// loadSession reads the latest session of the user at the test from the
+// sessions read model. A user who never started gets a NOT_STARTED session.
+func (u *answersheetUsecase) loadSession(ctx context.Context, userId int, testId int) (*entities.Session, error) {
+	session, err := u.repository.FindLatestSession(ctx, userId, testId)
+	if errors.Is(err, entities.ErrNotFound) {
+		return entities.NewSession(userId, testId), nil
+	}
 	if err != nil {
 		log.Error().Err(err).Send()
-		return false, err
+		return nil, err
 	}
+	return session, nil
+}
 
-	if len(list) == 0 {
-		return false, nil
+// sessionTest is the version of the test the session started with, nil when
+// it was not synced then. Later versions do not change a running session.
+func (u *answersheetUsecase) sessionTest(ctx context.Context, session *entities.Session) (*entities.Test, error) {
+	test, err := u.testUsecase.GetByTestIdAndVersion(ctx, session.TestId, session.TestVersion)
+	if errors.Is(err, entities.ErrNotFound) {
+		return nil, nil
 	}
+	return test, err
+}
 
-	if len(list) == 1 {
-		if list[0].Event == entities.START || list[0].Event == entities.DOING {
-			return true, nil
-		}
-		return false, nil
+func (u *answersheetUsecase) StartTest(ctx context.Context, input dto.StartTestInput) (*entities.Event, error) {
+	duplicate, err := u.findDuplicate(ctx, input.IdempotencyKey)
+	if err != nil || duplicate != nil {
+		return duplicate, err
 	}
 
-	first, second := list[0], list[1]
+	current, err := u.loadSession(ctx, input.Payload.UserId, input.Payload.TestId)
+	if err != nil {
+		return nil, err
+	}
+	if current.IsActive(time.Now()) {
+		log.Info().Int("user_id", current.UserId).Int("test_id", current.TestId).Str("session", current.SessionId).Msg("session in progress")
+		return nil, ErrSessionInProgress
+	}
 
-	if first.Event == entities.END && second.Event == entities.START {
-		return false, nil
+	test, err := u.testUsecase.GetByTestId(ctx, input.Payload.TestId)
+	if err != nil && !errors.Is(err, entities.ErrNotFound) {
+		return nil, err
+	}
+	accommodation, err := u.accommodations.GetAccommodation(ctx, input.Payload.TestId, input.Payload.UserId)
+	if err != nil {
+		return nil, err
 	}
 
-	return true, nil
-}
+	sessionId := uuid.New()
+	e := entities.Event{
+		UserId:  input.Payload.UserId,
+		TestId:  input.Payload.TestId,
+		Event:   entities.START,
+		Id:      primitive.NewObjectID(),
+		Session: sessionId.String(),
+
+		IdempotencyKey: input.IdempotencyKey,
+		Extension:      accommodation.Extra(test.TimeAllowed()),
+	}
+	if test != nil {
+		e.TestVersion = test.Version
+	}
+	e.Shuffle = entities.NewShuffle(e.Session, test)
+	u.stamp(&e, input.Payload.CreatedAt)
+	session := entities.NewSession(e.UserId, e.TestId)
+	session.Attempt = current.Attempt + 1
+	if err := session.Apply(&e); err != nil {
+		return nil, err
+	}
+	session.SetDeadline(test)
 
-func (u *answersheetUsecase) GetCurrentTest(ctx context.Context, testId int, userId int) ([]entities.Event, error) {
-	event, err := u.repository.GetLatestStartEvent(ctx, userId, testId)
+	outbox, err := newJobOutbox(input.JobId)
+	if err != nil {
+		return nil, err
+	}
+	err = u.repository.Create(ctx, &e, session, 0, outbox)
 	if err != nil {
 		log.Error().Err(err).Send()
 		return nil, err
 	}
-	return u.repository.FindAnswerByUserIdAndTestId(ctx, userId, testId, event.Session)
+
+	log.Info().Int("user_id", e.UserId).Int("test_id", e.TestId).Msg("user start test")
+	u.notify(entities.UPDATE_STATE, session, nil)
+
+	return &e, nil
+
 }
 
-func (u *answersheetUsecase) GetLatestStartTime(ctx context.Context, testId int, userId int) (*time.Time, error) {
-	ctx, span := tracer.Start(ctx, "get latest from mongodb")
+func (u *answersheetUsecase) CheckUserDoingTest(ctx context.Context, userId int, testId int) (*dto.SessionStatusOutput, error) {
+	ctx, span := tracer.Start(ctx, "get lastest event ", trace.WithAttributes(attribute.Int("userId", userId), attribute.Int("testId", testId)))
 	defer span.End()
-	event, err := u.repository.GetLatestStartEvent(ctx, userId, testId)
-	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
-		log.Error().Err(err).Send()
+	session, err := u.loadSession(ctx, userId, testId)
+	if err != nil {
 		return nil, err
 	}
-	if err != nil && errors.Is(err, mongo.ErrNoDocuments) {
-		log.Error().Err(err).Send()
-		return nil, nil
+
+	now := time.Now()
+	return &dto.SessionStatusOutput{Doing: session.IsActive(now), State: session.StateAt(now)}, nil
+}
+
+func (u *answersheetUsecase) GetCurrentTest(ctx context.Context, testId int, userId int) (*dto.CurrentTestOutput, error) {
+	session, err := u.loadSession(ctx, userId, testId)
+	if err != nil {
+		return nil, err
+	}
+	if session.State == entities.SESSION_NOT_STARTED {
+		return nil, ErrSessionNotStarted
 	}
 
-	result := event.CreatedAt
+	answers := session.SortedAnswers()
+	result := make([]entities.Event, 0, len(answers))
+	for _, item := range answers {
+		result = append(result, entities.Event{
+			UserId:     userId,
+			TestId:     testId,
+			Event:      entities.ANSWER,
+			Session:    session.SessionId,
+			QuestionId: item.QuestionId,
+			Answer:     item.Answer,
+			CreatedAt:  item.AnsweredAt,
+		})
+	}
+	return &dto.CurrentTestOutput{Answers: result, Flagged: session.Flagged, Shuffle: session.Shuffle}, nil
+}
+
+// GetLatestStartTime returns when the latest session of the user at the test
+// started and its deadline, accommodations and extensions included.
+func (u *answersheetUsecase) GetLatestStartTime(ctx context.Context, testId int, userId int) (*dto.StartTimeOutput, error) {
+	ctx, span := tracer.Start(ctx, "get latest from mongodb")
+	defer span.End()
+	session, err := u.loadSession(ctx, userId, testId)
+	if err != nil {
+		return nil, err
+	}
 
 	//t, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
 	//convert := result.In(t)
 
 	//zap.S().Info(convert.Format("15:04:05 02/01/2006"))
-	return result, nil
+	remaining, _ := session.Remaining(time.Now())
+	return &dto.StartTimeOutput{StartedAt: session.StartedAt, Deadline: session.Deadline, Remaining: remaining}, nil
 }
 
 func (u *answersheetUsecase) NotifyJobFail(ctx context.Context, jobId int, errFail error) error {
-	jobFail := &kafka.Writer{
-		Addr:                   kafka.TCP(strings.Split(u.config.GetKafkaBroker(), ",")...),
-		Topic:                  "job-fail",
-		AllowAutoTopicCreation: true,
-		BatchSize:              1,
-	}
-
-	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(map[string]interface{}{
-		"job_id":        jobId,
-		"error_message": errFail.Error(),
-	})
+	outbox, err := newJobFailOutbox(jobId, errFail)
 	if err != nil {
 		log.Error().Err(err).Send()
 		return err
 	}
 
-	err = jobFail.WriteMessages(ctx, kafka.Message{
-		Value: b.Bytes(),
-	})
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return u.outbox.Create(ctx, outbox)
 }
 
 func (u *answersheetUsecase) NotifyJobSuccess(ctx context.Context, jobId int) error {
-	jobSuccess := &kafka.Writer{
-		Addr:                   kafka.TCP(strings.Split(u.config.GetKafkaBroker(), ",")...),
-		Topic:                  "job-success",
-		AllowAutoTopicCreation: true,
-		Balancer:               &kafka.LeastBytes{},
-		BatchSize:              1,
-	}
-
-	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(map[string]interface{}{
-		"job_id": jobId,
-	})
+	outbox, err := newJobSuccessOutbox(jobId)
 	if err != nil {
 		log.Error().Err(err).Send()
 		return err
 	}
 
-	err = jobSuccess.WriteMessages(ctx, kafka.Message{
-		Value: b.Bytes(),
-	})
-	if err != nil {
-		return err
-	}
+	return u.outbox.Create(ctx, outbox)
+}
 
-	return nil
+// PushToDeadLetterQueue keeps the original key, value and headers of a message
+// that cannot be processed and records where it came from and why.
+func (u *answersheetUsecase) PushToDeadLetterQueue(ctx context.Context, message kafka.Message, reason error) error {
+	headers := append([]kafka.Header{}, message.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: "dlq-topic", Value: []byte(message.Topic)},
+		kafka.Header{Key: "dlq-partition", Value: []byte(strconv.Itoa(message.Partition))},
+		kafka.Header{Key: "dlq-offset", Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		kafka.Header{Key: "dlq-error", Value: []byte(reason.Error())},
+	)
+	return u.publisher.Publish(ctx, "dead-letter-queue", kafka.Message{
+		Key:     message.Key,
+		Value:   message.Value,
+		Headers: headers,
+	})
 }
 
-func (u *answersheetUsecase) PushToDeadLetterQueue(ctx context.Context, value []byte) error {
-	w := &kafka.Writer{
-		Addr:                   kafka.TCP(strings.Split(u.config.GetKafkaBroker(), ",")...),
-		Topic:                  "dead-letter-queue",
-		AllowAutoTopicCreation: true,
-		Balancer:               &kafka.LeastBytes{},
-		BatchSize:              1,
+func (u *answersheetUsecase) UserAnswer(ctx context.Context, input dto.UserAnswerInput) (*entities.Event, error) {
+	if err := validateStruct(input); err != nil {
+		return nil, err
 	}
-
-	err := w.WriteMessages(ctx, kafka.Message{
-		Value: value,
-	})
+	duplicate, err := u.findDuplicate(ctx, input.IdempotencyKey)
+	if err != nil || duplicate != nil {
+		return duplicate, err
+	}
+	session, err := u.loadSession(ctx, input.Payload.UserId, input.Payload.TestId)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	return nil
-
-}
-
-func (u *answersheetUsecase) UserAnswer(ctx context.Context, input dto.UserAnswerInput) error {
-	validate := validator.New()
-	if err := validate.Struct(input); err != nil {
-		return err
+	now := time.Now()
+	switch session.StateAt(now) {
+	case entities.SESSION_NOT_STARTED:
+		// no open session yet, this answer belongs to a START we have not seen yet
+		return nil, ErrSessionNotStarted
+	case entities.SESSION_SUBMITTED:
+		// the latest session is the submitted one, no retake has started that
+		// the answer could belong to. How late it is comes from the server
+		// clock, the client time is not trusted.
+		logger := log.Info().Int("user_id", session.UserId).Int("test_id", session.TestId).Str("session", session.SessionId)
+		if session.EndedAt != nil {
+			logger = logger.Dur("after_end", now.Sub(*session.EndedAt))
+		}
+		logger.Msg("answer after submit")
+		return nil, &AnswerAfterSubmitError{SessionId: session.SessionId, QuestionId: input.Payload.QuestionId}
 	}
-	event, err := u.repository.GetLatestStartEvent(ctx, input.Payload.UserId, input.Payload.TestId)
+	if err := session.Can(entities.ANSWER, now); err != nil {
+		return nil, err
+	}
+	test, err := u.sessionTest(ctx, session)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	sessionId := event.Session
+	if err := validateAnswer(input, test); err != nil {
+		log.Info().Err(err).Int("user_id", session.UserId).Int("test_id", session.TestId).Msg("invalid answer")
+		return nil, err
+	}
+	sessionId := session.SessionId
 
 	e := entities.Event{
 		Id:             primitive.NewObjectID(),
 		UserId:         input.Payload.UserId,
 		TestId:         input.Payload.TestId,
-		Event:          input.Payload.Event,
+		Event:          entities.ANSWER,
 		Session:        sessionId,
 		Answer:         input.Payload.Answer,
-		CreatedAt:      input.Payload.CreatedAt,
-		UpdatedAt:      input.Payload.UpdatedAt,
 		PreviousAnswer: input.Payload.PreviousAnswer,
 		QuestionId:     input.Payload.QuestionId,
+		IdempotencyKey: input.IdempotencyKey,
 	}
+	u.stamp(&e, input.Payload.CreatedAt)
 
-	if err := u.repository.Create(ctx, &e); err != nil {
-		return err
+	expected := session.Version
+	if err := session.Apply(&e); err != nil {
+		return nil, err
+	}
+
+	outbox, err := newJobOutbox(input.JobId)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repository.Create(ctx, &e, session, expected, outbox); err != nil {
+		return nil, err
 	}
+	answer := session.Answers[e.QuestionId]
+	u.notify(entities.UPDATE_ANSWER, session, &answer)
 
-	return nil
+	return &e, nil
+}
+
+// stamp gives the event the server receive time and logs a client clock too
+// far from it.
+func (u *answersheetUsecase) stamp(e *entities.Event, clientTime *time.Time) {
+	e.Stamp(time.Now(), clientTime, u.config.GetMaxClockSkew())
+	if e.SkewFlagged {
+		log.Warn().Int("user_id", e.UserId).Int("test_id", e.TestId).Str("event", e.Event).Dur("skew", e.ClockSkew).Msg("client clock skew")
+	}
 }
 
 func (u *answersheetUsecase) CheckUserSubmitted(ctx context.Context, userId int, testId int) (bool, error) {
-	result, err := u.repository.FindByStatusEnd(ctx, userId, testId)
+	session, err := u.loadSession(ctx, userId, testId)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return false, nil
-		}
-		log.Error().Err(err).Send()
 		return false, err
 	}
-	if result != nil {
-		return true, nil
-	}
 
-	return false, nil
+	return session.State == entities.SESSION_SUBMITTED, nil
 }
 
-func (u *answersheetUsecase) SubmitTest(ctx context.Context, input dto.SubmitTestInput) error {
-	check, err := u.CheckUserSubmitted(ctx, input.Payload.UserId, input.Payload.TestId)
+func (u *answersheetUsecase) SubmitTest(ctx context.Context, input dto.SubmitTestInput) (*entities.Event, error) {
+	duplicate, err := u.findDuplicate(ctx, input.IdempotencyKey)
+	if err != nil || duplicate != nil {
+		return duplicate, err
+	}
+	session, err := u.loadSession(ctx, input.Payload.UserId, input.Payload.TestId)
 	if err != nil {
-		log.Error().Err(err).Send()
-		return err
+		return nil, err
 	}
-	if check {
+	now := time.Now()
+	switch session.StateAt(now) {
+	case entities.SESSION_NOT_STARTED:
+		return nil, ErrSessionNotStarted
+	case entities.SESSION_SUBMITTED:
 		log.Info().Int("user_id", input.Payload.UserId).Int("test_id", input.Payload.TestId).Msg("user submitted")
-		return ErrUserSubmitted
+		return nil, ErrUserSubmitted
 	}
-	event, err := u.repository.GetLatestStartEvent(ctx, input.Payload.UserId, input.Payload.TestId)
-	if err != nil {
-		log.Error().Err(err).Send()
-		return err
+	if err := session.Can(entities.END, now); err != nil {
+		return nil, err
 	}
-	sessionId := event.Session
+	sessionId := session.SessionId
 	e := entities.Event{
-		UserId:    input.Payload.UserId,
-		TestId:    input.Payload.TestId,
-		CreatedAt: input.Payload.CreatedAt,
-		UpdatedAt: input.Payload.UpdatedAt,
-		Event:     entities.END,
-		Id:        primitive.NewObjectID(),
-		Session:   sessionId,
+		UserId:  input.Payload.UserId,
+		TestId:  input.Payload.TestId,
+		Event:   entities.END,
+		Id:      primitive.NewObjectID(),
+		Session: sessionId,
+
+		IdempotencyKey: input.IdempotencyKey,
 	}
+	u.stamp(&e, input.Payload.CreatedAt)
 
-	err = u.repository.Create(ctx, &e)
+	expected := session.Version
+	if err := session.Apply(&e); err != nil {
+		return nil, err
+	}
+
+	outbox, err := newJobOutbox(input.JobId)
+	if err != nil {
+		return nil, err
+	}
+	err = u.repository.Create(ctx, &e, session, expected, outbox)
 	if err != nil {
 		log.Error().Err(err).Send()
-		return err
+		return nil, err
+	}
+	u.notify(entities.UPDATE_STATE, session, nil)
+
+	return &e, nil
+}
+
+// SubmitExpired writes the END of up to limit sessions still in progress past
+// their deadline and tells their watchers. A session changed meanwhile is left
+// to the next run. It returns how many sessions were submitted.
+func (u *answersheetUsecase) SubmitExpired(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+	sessions, err := u.repository.FindExpiredSessions(ctx, now, limit)
+	if err != nil {
+		log.Error().Err(err).Send()
+		return 0, err
 	}
 
-	return nil
+	count := 0
+	for index := range sessions {
+		session := &sessions[index]
+		if err := session.Can(entities.END, now); err != nil {
+			continue
+		}
+		e := entities.Event{
+			Id:      primitive.NewObjectID(),
+			UserId:  session.UserId,
+			TestId:  session.TestId,
+			Event:   entities.END,
+			Session: session.SessionId,
+			Reason:  entities.REASON_DEADLINE,
+		}
+		e.Stamp(now, nil, 0)
+		expected := session.Version
+		if err := session.Apply(&e); err != nil {
+			return count, err
+		}
+		err := u.repository.Create(ctx, &e, session, expected, nil)
+		if errors.Is(err, entities.ErrVersionConflict) {
+			continue
+		}
+		if err != nil {
+			log.Error().Err(err).Str("session", session.SessionId).Send()
+			return count, err
+		}
+		log.Info().Int("user_id", session.UserId).Int("test_id", session.TestId).Str("session", session.SessionId).Msg("session submitted at deadline")
+		u.notify(entities.UPDATE_FORCE_SUBMIT, session, nil)
+		count++
+	}
+	return count, nil
+}
+
+func (u *answersheetUsecase) notify(updateType string, session *entities.Session, answer *entities.SessionAnswer) {
+	u.hub.Publish(entities.SessionUpdate{Type: updateType, Session: *session, Answer: answer, At: time.Now()})
 }
 
 func (u *answersheetUsecase) GetScore(ctx context.Context, input dto.GetScoreInput) (*dto.GetScoreOutput, error) {
-	submitted, err := u.CheckUserSubmitted(ctx, input.UserId, input.TestId)
+	session, err := u.loadSession(ctx, input.UserId, input.TestId)
 	if err != nil {
 		return nil, err
 	}
-	if !submitted {
+	if session.State != entities.SESSION_SUBMITTED {
 		return nil, errors.New("user didn't submit")
 	}
-	test, err := u.testUsecase.GetByTestId(ctx, input.TestId)
+	// the answer key the session was shuffled against
+	test, err := u.testUsecase.GetByTestIdAndVersion(ctx, input.TestId, session.TestVersion)
 	if err != nil {
 		log.Error().Err(err).Send()
 		return nil, err
 	}
 
-	answers, err := u.GetCurrentTest(ctx, input.TestId, input.UserId)
+	// only answers stored before the END of the session count
+	answers, err := u.repository.FindAnswerByUserIdAndTestId(ctx, input.UserId, input.TestId, session.SessionId)
 	if err != nil {
 		log.Error().Err(err).Send()
 		return nil, err
 	}
 
 	var score float32
-	if test.Content.MultipleChoice != nil {
+	if test.Content != nil && test.Content.MultipleChoice != nil {
 		if test.Content.MultipleChoice.Answers != nil {
 			mapAnswers := make(map[int]string)
 			for _, item := range answers {
@@ -321,7 +503,7 @@ func (u *answersheetUsecase) GetScore(ctx context.Context, input dto.GetScoreInp
 				if !ok {
 					continue
 				}
-				if answer == item.Answer {
+				if session.Shuffle.Canonical(item.Id, answer) == item.Answer {
 					score += float32(item.Score)
 				}
 			}
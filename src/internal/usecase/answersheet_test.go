@@ -0,0 +1,1082 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"picket-answersheet-service/src/internal/mocks"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func startInput() dto.StartTestInput {
+	var input dto.StartTestInput
+	input.JobId = 10
+	input.IdempotencyKey = "start-1"
+	input.Payload.UserId = 1
+	input.Payload.TestId = 2
+	now := time.Now()
+	input.Payload.CreatedAt = &now
+	return input
+}
+
+func answerInput(questionId int, answer string) dto.UserAnswerInput {
+	var input dto.UserAnswerInput
+	input.JobId = 11
+	input.IdempotencyKey = "answer-1"
+	input.Payload.UserId = 1
+	input.Payload.TestId = 2
+	now := time.Now()
+	input.Payload.CreatedAt = &now
+	input.Payload.QuestionId = questionId
+	input.Payload.Answer = answer
+	return input
+}
+
+func submitInput() dto.SubmitTestInput {
+	var input dto.SubmitTestInput
+	input.JobId = 12
+	input.IdempotencyKey = "submit-1"
+	input.Payload.UserId = 1
+	input.Payload.TestId = 2
+	now := time.Now()
+	input.Payload.CreatedAt = &now
+	return input
+}
+
+func TestAnswersheetUsecase_StartTest(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   dto.StartTestInput
+		setup   func(f *answersheetFixture)
+		wantErr error
+	}{
+		{
+			name: "first start creates a session with a deadline",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(nil)
+				f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(&entities.Test{TestId: 2, TimeToDo: 60, Version: 3}, nil)
+				f.noAccommodation()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						if event.Event != entities.START || event.Session == "" || event.IdempotencyKey != "start-1" || event.TestVersion != 3 {
+							t.Errorf("unexpected event %+v", event)
+						}
+						if session.State != entities.SESSION_IN_PROGRESS || session.SessionId != event.Session || session.Deadline == nil || session.TestVersion != 3 {
+							t.Errorf("unexpected session %+v", session)
+						}
+						if expectedVersion != 0 || session.Attempt != 1 {
+							t.Errorf("unexpected attempt %d at version %d", session.Attempt, expectedVersion)
+						}
+						if outbox.Topic != "job-success" {
+							t.Errorf("unexpected outbox topic %s", outbox.Topic)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "every session is shuffled and the shuffle is kept on the START event",
+			setup: func(f *answersheetFixture) {
+				test := &entities.Test{TestId: 2, TimeToDo: 60, Content: &entities.TestContent{MultipleChoice: &entities.TestMultipleChoice{
+					Answers: []entities.TestMultipleChoiceAnswer{
+						{Id: 1, Answer: "A", Options: 6},
+						{Id: 2, Answer: "B,C", Type: entities.QUESTION_MULTIPLE},
+						{Id: 3, Answer: "42", Type: entities.QUESTION_NUMBER},
+					},
+				}}}
+				f.noDuplicate("start-1")
+				f.latestSession(nil)
+				f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(test, nil)
+				f.noAccommodation()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						shuffle := session.Shuffle
+						if shuffle == nil || !reflect.DeepEqual(shuffle, entities.NewShuffle(session.SessionId, test)) {
+							t.Fatalf("shuffle %+v is not the one of session %s", shuffle, session.SessionId)
+						}
+						if event.Shuffle != shuffle {
+							t.Errorf("START event shuffle %+v, want the session's", event.Shuffle)
+						}
+						questions := append([]int{}, shuffle.Questions...)
+						sort.Ints(questions)
+						if !reflect.DeepEqual(questions, []int{1, 2, 3}) {
+							t.Errorf("question order %v", shuffle.Questions)
+						}
+						options := append([]string{}, shuffle.Options[2]...)
+						sort.Strings(options)
+						if len(shuffle.Options) != 2 || !reflect.DeepEqual(options, entities.OPTIONS) {
+							t.Errorf("options %v", shuffle.Options)
+						}
+						options = append([]string{}, shuffle.Options[1]...)
+						sort.Strings(options)
+						if !reflect.DeepEqual(options, entities.OptionLabels(6)) {
+							t.Errorf("options of question 1 %v, want six", shuffle.Options[1])
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "accommodation of the user extends the deadline",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(nil)
+				f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(&entities.Test{TestId: 2, TimeToDo: 60}, nil)
+				f.accommodations.EXPECT().GetAccommodation(gomock.Any(), 2, 1).Return(&entities.Accommodation{TestId: 2, UserId: 1, ExtraMinutes: 10, Multiplier: 1.5}, nil)
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						if event.Extension != 40*time.Minute {
+							t.Errorf("extension = %v, want 40m", event.Extension)
+						}
+						if got := session.Deadline.Sub(*session.StartedAt); got != 100*time.Minute {
+							t.Errorf("time allowed = %v, want 100m", got)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "accommodation lookup error",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(nil)
+				f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(&entities.Test{TestId: 2, TimeToDo: 60}, nil)
+				f.accommodations.EXPECT().GetAccommodation(gomock.Any(), 2, 1).Return(nil, errStorage)
+			},
+			wantErr: errStorage,
+		},
+		{
+			name: "deadline runs from the server time, not a skewed client time",
+			input: func() dto.StartTestInput {
+				input := startInput()
+				client := time.Now().Add(-2 * time.Hour)
+				input.Payload.CreatedAt = &client
+				return input
+			}(),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(nil)
+				f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(&entities.Test{TestId: 2, TimeToDo: 60}, nil)
+				f.noAccommodation()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						if event.ClientCreatedAt == nil || !event.SkewFlagged || event.ClockSkew < time.Hour {
+							t.Errorf("unexpected client time %v, skew %v flagged %v", event.ClientCreatedAt, event.ClockSkew, event.SkewFlagged)
+						}
+						if time.Since(*session.StartedAt) > time.Minute || !session.IsActive(time.Now()) {
+							t.Errorf("unexpected session %+v", session)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "start without a client time",
+			input: func() dto.StartTestInput {
+				input := startInput()
+				input.Payload.CreatedAt = nil
+				return input
+			}(),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(nil)
+				f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(&entities.Test{TestId: 2, TimeToDo: 60}, nil)
+				f.noAccommodation()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						if event.ClientCreatedAt != nil || event.SkewFlagged || session.StartedAt == nil || session.Deadline == nil {
+							t.Errorf("unexpected event %+v session %+v", event, session)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "unknown test starts without a deadline",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(nil)
+				f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(nil, entities.ErrNotFound)
+				f.noAccommodation()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						if session.Deadline != nil {
+							t.Errorf("unexpected deadline %v", session.Deadline)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "expired session can be started again",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(expired())
+				f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(&entities.Test{TestId: 2}, nil)
+				f.noAccommodation()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						if session.SessionId == "session-1" || session.Attempt != 2 {
+							t.Errorf("unexpected session %+v", session)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "concurrent start of the same attempt",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(nil)
+				f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(&entities.Test{TestId: 2}, nil)
+				f.noAccommodation()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 0, gomock.Any()).Return(entities.ErrVersionConflict)
+			},
+			wantErr: entities.ErrVersionConflict,
+		},
+		{
+			name: "duplicate idempotency key is a no-op",
+			setup: func(f *answersheetFixture) {
+				f.repository.EXPECT().FindByIdempotencyKey(gomock.Any(), "start-1").Return(&entities.Event{}, nil)
+			},
+		},
+		{
+			name: "idempotency lookup error",
+			setup: func(f *answersheetFixture) {
+				f.repository.EXPECT().FindByIdempotencyKey(gomock.Any(), "start-1").Return(nil, errStorage)
+			},
+			wantErr: errStorage,
+		},
+		{
+			name: "session in progress",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(inProgress())
+			},
+			wantErr: ErrSessionInProgress,
+		},
+		{
+			name: "paused session is still in progress",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(withState(inProgress(), entities.SESSION_PAUSED))
+			},
+			wantErr: ErrSessionInProgress,
+		},
+		{
+			name: "session lookup error",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(nil, errStorage)
+			},
+			wantErr: errStorage,
+		},
+		{
+			name: "test lookup error",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(nil)
+				f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(nil, errStorage)
+			},
+			wantErr: errStorage,
+		},
+		{
+			name: "create error",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("start-1")
+				f.latestSession(nil)
+				f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(&entities.Test{TestId: 2}, nil)
+				f.noAccommodation()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errStorage)
+			},
+			wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			tt.setup(f)
+			input := tt.input
+			if input.IdempotencyKey == "" {
+				input = startInput()
+			}
+			if _, err := f.usecase.StartTest(context.Background(), input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("StartTest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAnswersheetUsecase_StartTest_ReturnsEvent(t *testing.T) {
+	t.Run("stored event without job has no outbox", func(t *testing.T) {
+		f := newAnswersheetFixture(t)
+		f.noDuplicate("start-1")
+		f.latestSession(nil)
+		f.testUsecase.EXPECT().GetByTestId(gomock.Any(), 2).Return(&entities.Test{TestId: 2, TimeToDo: 60}, nil)
+		f.noAccommodation()
+		f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 0, nil).Return(nil)
+
+		input := startInput()
+		input.JobId = 0
+		event, err := f.usecase.StartTest(context.Background(), input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if event == nil || event.Event != entities.START || event.Session == "" {
+			t.Fatalf("StartTest() = %+v", event)
+		}
+	})
+
+	t.Run("duplicate returns the stored event", func(t *testing.T) {
+		f := newAnswersheetFixture(t)
+		stored := &entities.Event{Id: primitive.NewObjectID(), Event: entities.START, Session: "session-1"}
+		f.repository.EXPECT().FindByIdempotencyKey(gomock.Any(), "start-1").Return(stored, nil)
+
+		event, err := f.usecase.StartTest(context.Background(), startInput())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if event != stored {
+			t.Fatalf("StartTest() = %+v, want %+v", event, stored)
+		}
+	})
+}
+
+func TestAnswersheetUsecase_UserAnswer(t *testing.T) {
+	var transitionErr *entities.TransitionError
+	var afterSubmitErr *AnswerAfterSubmitError
+	var validationErr *ValidationError
+
+	tests := []struct {
+		name    string
+		input   dto.UserAnswerInput
+		setup   func(f *answersheetFixture)
+		wantErr error
+		wantAs  interface{}
+	}{
+		{
+			name:  "answer is stored and projected",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(inProgress())
+				f.answerKey()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						if event.Event != entities.ANSWER || event.Session != "session-1" || event.QuestionId != 3 {
+							t.Errorf("unexpected event %+v", event)
+						}
+						if session.Answers[3].Answer != "B" || session.Version != 2 || expectedVersion != 1 {
+							t.Errorf("unexpected session %+v at version %d", session, expectedVersion)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "answer with a client clock past the deadline is stored at the server time",
+			input: func() dto.UserAnswerInput {
+				input := answerInput(3, "B")
+				ahead := time.Now().Add(3 * time.Hour)
+				input.Payload.CreatedAt = &ahead
+				return input
+			}(),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(inProgress())
+				f.answerKey()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						if event.CreatedAt == nil || time.Since(*event.CreatedAt) > time.Minute || !event.SkewFlagged || event.ClockSkew > -time.Hour {
+							t.Errorf("unexpected stamp %v, skew %v flagged %v", event.CreatedAt, event.ClockSkew, event.SkewFlagged)
+						}
+						if answeredAt := session.Answers[3].AnsweredAt; answeredAt == nil || !answeredAt.Equal(*event.CreatedAt) {
+							t.Errorf("answered at %v, want the server time %v", answeredAt, event.CreatedAt)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name:  "answer to a test not synced yet is stored",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(inProgress())
+				f.testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, gomock.Any()).Return(nil, entities.ErrNotFound)
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:   "missing answer",
+			input:  answerInput(3, ""),
+			setup:  func(f *answersheetFixture) {},
+			wantAs: &validationErr,
+		},
+		{
+			name:  "question not in the test",
+			input: answerInput(9, "A"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(inProgress())
+				f.answerKey()
+			},
+			wantAs: &validationErr,
+		},
+		{
+			name:  "answer is checked against the options of the version the session started with",
+			input: answerInput(3, "E"),
+			setup: func(f *answersheetFixture) {
+				session := inProgress()
+				session.TestVersion = 3
+				f.noDuplicate("answer-1")
+				f.latestSession(session)
+				f.testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, 3).Return(&entities.Test{TestId: 2, Version: 3, Content: &entities.TestContent{MultipleChoice: &entities.TestMultipleChoice{
+					Answers: []entities.TestMultipleChoiceAnswer{{Id: 3, Answer: "E", Options: 5}},
+				}}}, nil)
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:  "option not on the sheet",
+			input: answerInput(3, "E"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(inProgress())
+				f.answerKey()
+			},
+			wantAs: &validationErr,
+		},
+		{
+			name:  "test lookup error",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(inProgress())
+				f.testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, gomock.Any()).Return(nil, errStorage)
+			},
+			wantErr: errStorage,
+		},
+		{
+			name:  "duplicate idempotency key is a no-op",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.repository.EXPECT().FindByIdempotencyKey(gomock.Any(), "answer-1").Return(&entities.Event{}, nil)
+			},
+		},
+		{
+			name:  "no session yet",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(nil)
+			},
+			wantErr: ErrSessionNotStarted,
+		},
+		{
+			name:  "answer made after the submit is rejected",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(submittedAt(time.Now().Add(-time.Minute)))
+			},
+			wantAs: &afterSubmitErr,
+		},
+		{
+			name: "answer with a future client time is rejected",
+			input: func() dto.UserAnswerInput {
+				input := answerInput(3, "B")
+				future := time.Now().Add(time.Hour)
+				input.Payload.CreatedAt = &future
+				return input
+			}(),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(submittedAt(time.Now().Add(-time.Minute)))
+			},
+			wantAs: &afterSubmitErr,
+		},
+		{
+			name:  "answer made before the submit is rejected",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(submittedAt(time.Now().Add(time.Minute)))
+			},
+			wantAs: &afterSubmitErr,
+		},
+		{
+			name: "answer without a time after the submit is rejected",
+			input: func() dto.UserAnswerInput {
+				input := answerInput(3, "B")
+				input.Payload.CreatedAt = nil
+				return input
+			}(),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(submittedAt(time.Now().Add(-time.Minute)))
+			},
+			wantAs: &afterSubmitErr,
+		},
+		{
+			name:  "expired session refuses answers",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(expired())
+			},
+			wantAs: &transitionErr,
+		},
+		{
+			name:  "paused session refuses answers",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(withState(inProgress(), entities.SESSION_PAUSED))
+			},
+			wantAs: &transitionErr,
+		},
+		{
+			name:  "session lookup error",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(nil, errStorage)
+			},
+			wantErr: errStorage,
+		},
+		{
+			name:  "create error",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(inProgress())
+				f.answerKey()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errStorage)
+			},
+			wantErr: errStorage,
+		},
+		{
+			name:  "session moved on since it was read",
+			input: answerInput(3, "B"),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("answer-1")
+				f.latestSession(inProgress())
+				f.answerKey()
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(entities.ErrVersionConflict)
+			},
+			wantErr: entities.ErrVersionConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			tt.setup(f)
+			_, err := f.usecase.UserAnswer(context.Background(), tt.input)
+			if tt.wantAs != nil {
+				if !errors.As(err, tt.wantAs) {
+					t.Fatalf("UserAnswer() error = %v, want %T", err, tt.wantAs)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UserAnswer() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAnswersheetUsecase_SubmitTest(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(f *answersheetFixture)
+		wantErr error
+	}{
+		{
+			name: "submit closes the session",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("submit-1")
+				f.latestSession(inProgress())
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						if event.Event != entities.END || session.State != entities.SESSION_SUBMITTED {
+							t.Errorf("unexpected event %+v session %+v", event, session)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "expired session can still be submitted",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("submit-1")
+				f.latestSession(expired())
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "duplicate idempotency key is a no-op",
+			setup: func(f *answersheetFixture) {
+				f.repository.EXPECT().FindByIdempotencyKey(gomock.Any(), "submit-1").Return(&entities.Event{}, nil)
+			},
+		},
+		{
+			name: "no session yet",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("submit-1")
+				f.latestSession(nil)
+			},
+			wantErr: ErrSessionNotStarted,
+		},
+		{
+			name: "already submitted",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("submit-1")
+				f.latestSession(withState(inProgress(), entities.SESSION_SUBMITTED))
+			},
+			wantErr: ErrUserSubmitted,
+		},
+		{
+			name: "create error",
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("submit-1")
+				f.latestSession(inProgress())
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errStorage)
+			},
+			wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			tt.setup(f)
+			if _, err := f.usecase.SubmitTest(context.Background(), submitInput()); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SubmitTest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAnswersheetUsecase_CheckUserDoingTest(t *testing.T) {
+	tests := []struct {
+		name    string
+		session *entities.Session
+		err     error
+		want    bool
+		state   string
+		wantErr error
+	}{
+		{name: "never started", want: false, state: entities.SESSION_NOT_STARTED},
+		{name: "in progress", session: inProgress(), want: true, state: entities.SESSION_IN_PROGRESS},
+		{name: "paused", session: withState(inProgress(), entities.SESSION_PAUSED), want: true, state: entities.SESSION_PAUSED},
+		{name: "paused past the deadline", session: withState(expired(), entities.SESSION_PAUSED), want: true, state: entities.SESSION_PAUSED},
+		{name: "deadline passed", session: expired(), want: false, state: entities.SESSION_EXPIRED},
+		{name: "submitted", session: withState(inProgress(), entities.SESSION_SUBMITTED), want: false, state: entities.SESSION_SUBMITTED},
+		{name: "voided", session: withState(inProgress(), entities.SESSION_VOIDED), want: false, state: entities.SESSION_VOIDED},
+		{name: "lookup error", err: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			if tt.err != nil {
+				f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(nil, tt.err)
+			} else {
+				f.latestSession(tt.session)
+			}
+			got, err := f.usecase.CheckUserDoingTest(context.Background(), 1, 2)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckUserDoingTest() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Doing != tt.want || got.State != tt.state {
+				t.Fatalf("CheckUserDoingTest() = %+v, want %v %s", got, tt.want, tt.state)
+			}
+		})
+	}
+}
+
+func TestAnswersheetUsecase_CheckUserSubmitted(t *testing.T) {
+	tests := []struct {
+		name    string
+		session *entities.Session
+		err     error
+		want    bool
+		wantErr error
+	}{
+		{name: "never started", want: false},
+		{name: "in progress", session: inProgress(), want: false},
+		{name: "submitted", session: withState(inProgress(), entities.SESSION_SUBMITTED), want: true},
+		{name: "lookup error", err: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			if tt.err != nil {
+				f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(nil, tt.err)
+			} else {
+				f.latestSession(tt.session)
+			}
+			got, err := f.usecase.CheckUserSubmitted(context.Background(), 1, 2)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckUserSubmitted() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("CheckUserSubmitted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnswersheetUsecase_GetLatestStartTime(t *testing.T) {
+	session := inProgress()
+
+	tests := []struct {
+		name    string
+		session *entities.Session
+		err     error
+		want    *time.Time
+		wantErr error
+	}{
+		{name: "never started has no start time", want: nil},
+		{name: "started", session: session, want: session.StartedAt},
+		{name: "lookup error", err: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			if tt.err != nil {
+				f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(nil, tt.err)
+			} else {
+				f.latestSession(tt.session)
+			}
+			got, err := f.usecase.GetLatestStartTime(context.Background(), 2, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetLatestStartTime() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (got.StartedAt == nil) != (tt.want == nil) || (got.StartedAt != nil && !got.StartedAt.Equal(*tt.want)) {
+				t.Fatalf("GetLatestStartTime() = %v, want %v", got.StartedAt, tt.want)
+			}
+			if tt.session != nil && (got.Deadline == nil || !got.Deadline.Equal(*tt.session.Deadline)) {
+				t.Fatalf("GetLatestStartTime() deadline = %v, want %v", got.Deadline, tt.session.Deadline)
+			}
+		})
+	}
+}
+
+func TestAnswersheetUsecase_GetCurrentTest(t *testing.T) {
+	answered := inProgress()
+	answered.Answers[5] = entities.SessionAnswer{QuestionId: 5, Answer: "C"}
+	answered.Answers[1] = entities.SessionAnswer{QuestionId: 1, Answer: "A"}
+	shuffled := inProgress()
+	shuffled.Shuffle = &entities.Shuffle{Questions: []int{3, 1, 2}, Options: map[int][]string{1: {"D", "C", "B", "A"}}}
+
+	tests := []struct {
+		name    string
+		session *entities.Session
+		err     error
+		want    map[int]string
+		wantErr error
+	}{
+		{name: "never started", wantErr: ErrSessionNotStarted},
+		{name: "no answer yet", session: inProgress(), want: map[int]string{}},
+		{name: "answers sorted by question", session: answered, want: map[int]string{1: "A", 5: "C"}},
+		{name: "order of a shuffled session", session: shuffled, want: map[int]string{}},
+		{name: "lookup error", err: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			if tt.err != nil {
+				f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(nil, tt.err)
+			} else {
+				f.latestSession(tt.session)
+			}
+			got, err := f.usecase.GetCurrentTest(context.Background(), 2, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetCurrentTest() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			answers := make(map[int]string)
+			for index, item := range got.Answers {
+				if index > 0 && got.Answers[index-1].QuestionId >= item.QuestionId {
+					t.Fatalf("GetCurrentTest() not sorted: %+v", got.Answers)
+				}
+				answers[item.QuestionId] = item.Answer
+			}
+			if !reflect.DeepEqual(answers, tt.want) {
+				t.Fatalf("GetCurrentTest() = %v, want %v", answers, tt.want)
+			}
+			if got.Shuffle != tt.session.Shuffle {
+				t.Fatalf("GetCurrentTest() shuffle = %+v, want %+v", got.Shuffle, tt.session.Shuffle)
+			}
+		})
+	}
+}
+
+func TestAnswersheetUsecase_SubmitExpired(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(f *answersheetFixture)
+		want    int
+		wantErr error
+	}{
+		{
+			name: "expired sessions are submitted and watchers told",
+			setup: func(f *answersheetFixture) {
+				f.repository.EXPECT().FindExpiredSessions(gomock.Any(), gomock.Any(), 10).Return([]entities.Session{*expired()}, nil)
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 1, nil).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						if event.Event != entities.END || event.Reason != entities.REASON_DEADLINE || session.State != entities.SESSION_SUBMITTED {
+							t.Errorf("unexpected event %+v session %+v", event, session)
+						}
+						return nil
+					})
+				f.hub.EXPECT().Publish(gomock.Any()).Do(func(update entities.SessionUpdate) {
+					if update.Type != entities.UPDATE_FORCE_SUBMIT || update.Session.SessionId != "session-1" {
+						t.Errorf("unexpected update %+v", update)
+					}
+				})
+			},
+			want: 1,
+		},
+		{
+			name: "session changed meanwhile is skipped",
+			setup: func(f *answersheetFixture) {
+				f.repository.EXPECT().FindExpiredSessions(gomock.Any(), gomock.Any(), 10).Return([]entities.Session{*expired()}, nil)
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(entities.ErrVersionConflict)
+			},
+		},
+		{
+			name: "lookup error",
+			setup: func(f *answersheetFixture) {
+				f.repository.EXPECT().FindExpiredSessions(gomock.Any(), gomock.Any(), 10).Return(nil, errStorage)
+			},
+			wantErr: errStorage,
+		},
+		{
+			name: "create error",
+			setup: func(f *answersheetFixture) {
+				f.repository.EXPECT().FindExpiredSessions(gomock.Any(), gomock.Any(), 10).Return([]entities.Session{*expired()}, nil)
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errStorage)
+			},
+			wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			f := newAnswersheetFixture(t)
+			// replace the permissive hub of the fixture to check what is published
+			f.hub = mocks.NewMockIHub(ctrl)
+			f.usecase.hub = f.hub
+			tt.setup(f)
+			got, err := f.usecase.SubmitExpired(context.Background(), 10)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SubmitExpired() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("SubmitExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnswersheetUsecase_GetScore(t *testing.T) {
+	submitted := withState(inProgress(), entities.SESSION_SUBMITTED)
+	answers := []entities.Event{
+		{QuestionId: 1, Answer: "A"},
+		{QuestionId: 2, Answer: "wrong"},
+		{QuestionId: 9, Answer: "not in test"},
+	}
+
+	test := &entities.Test{TestId: 2, Content: &entities.TestContent{MultipleChoice: &entities.TestMultipleChoice{
+		Answers: []entities.TestMultipleChoiceAnswer{
+			{Id: 1, Answer: "A", Score: 1.5},
+			{Id: 2, Answer: "B", Score: 2},
+			{Id: 3, Answer: "C", Score: 4},
+		},
+	}}}
+
+	// shown B is the key's A, shown B of question 3 is the key's C and shown
+	// C,A of question 4 are the key's A and C
+	shuffled := withState(inProgress(), entities.SESSION_SUBMITTED)
+	shuffled.Shuffle = &entities.Shuffle{Questions: []int{4, 3, 2, 1}, Options: map[int][]string{
+		1: {"B", "A", "C", "D"},
+		3: {"D", "C", "B", "A"},
+		4: {"C", "D", "A", "B"},
+	}}
+	shuffled.TestVersion = 3
+	shuffledTest := &entities.Test{TestId: 2, Version: 3, Content: &entities.TestContent{MultipleChoice: &entities.TestMultipleChoice{
+		Answers: append(append([]entities.TestMultipleChoiceAnswer{}, test.Content.MultipleChoice.Answers...),
+			entities.TestMultipleChoiceAnswer{Id: 4, Answer: "A,C", Score: 1, Type: entities.QUESTION_MULTIPLE}),
+	}}}
+	shuffledAnswers := []entities.Event{
+		{QuestionId: 1, Answer: "B"},
+		{QuestionId: 2, Answer: "B"},
+		{QuestionId: 3, Answer: "B"},
+		{QuestionId: 4, Answer: "C,A"},
+	}
+
+	tests := []struct {
+		name       string
+		session    *entities.Session
+		test       *entities.Test
+		answers    []entities.Event
+		testErr    error
+		answersErr error
+		want       float32
+		wantErr    bool
+	}{
+		{name: "only matching answers score", session: submitted, test: test, want: 1.5},
+		{name: "shuffled labels are mapped back to the key of the version the session started with", session: shuffled, test: shuffledTest, answers: shuffledAnswers, want: 8.5},
+		{name: "test without content scores zero", session: submitted, test: &entities.Test{TestId: 2}, want: 0},
+		{name: "test without multiple choice scores zero", session: submitted, test: &entities.Test{TestId: 2, Content: &entities.TestContent{}}, want: 0},
+		{name: "not submitted", session: inProgress(), wantErr: true},
+		{name: "never started", wantErr: true},
+		{name: "test lookup error", session: submitted, testErr: errStorage, wantErr: true},
+		{name: "answers lookup error", session: submitted, test: test, answersErr: errStorage, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			f.latestSession(tt.session)
+			if tt.test != nil || tt.testErr != nil {
+				f.testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, tt.session.TestVersion).Return(tt.test, tt.testErr)
+			}
+			if tt.test != nil {
+				stored := answers
+				if tt.answers != nil {
+					stored = tt.answers
+				}
+				f.repository.EXPECT().FindAnswerByUserIdAndTestId(gomock.Any(), 1, 2, "session-1").Return(stored, tt.answersErr)
+			}
+			got, err := f.usecase.GetScore(context.Background(), dto.GetScoreInput{UserId: 1, TestId: 2})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetScore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Score != tt.want {
+				t.Fatalf("GetScore() = %v, want %v", got.Score, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnswersheetUsecase_Notify(t *testing.T) {
+	tests := []struct {
+		name      string
+		notify    func(u *answersheetUsecase) error
+		wantTopic string
+		createErr error
+	}{
+		{
+			name:      "job success",
+			notify:    func(u *answersheetUsecase) error { return u.NotifyJobSuccess(context.Background(), 7) },
+			wantTopic: "job-success",
+		},
+		{
+			name:      "job fail",
+			notify:    func(u *answersheetUsecase) error { return u.NotifyJobFail(context.Background(), 7, errStorage) },
+			wantTopic: "job-fail",
+		},
+		{
+			name:      "job success outbox error",
+			notify:    func(u *answersheetUsecase) error { return u.NotifyJobSuccess(context.Background(), 7) },
+			wantTopic: "job-success",
+			createErr: errStorage,
+		},
+		{
+			name:      "job fail outbox error",
+			notify:    func(u *answersheetUsecase) error { return u.NotifyJobFail(context.Background(), 7, errStorage) },
+			wantTopic: "job-fail",
+			createErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			f.outbox.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, outbox *entities.Outbox) error {
+				if outbox.Topic != tt.wantTopic || outbox.Status != entities.OUTBOX_PENDING {
+					t.Errorf("unexpected outbox %+v", outbox)
+				}
+				return tt.createErr
+			})
+			if err := tt.notify(f.usecase); !errors.Is(err, tt.createErr) {
+				t.Fatalf("notify error = %v, want %v", err, tt.createErr)
+			}
+		})
+	}
+}
+
+func TestAnswersheetUsecase_PushToDeadLetterQueue(t *testing.T) {
+	message := kafka.Message{
+		Topic:     "answersheet-events",
+		Partition: 3,
+		Offset:    42,
+		Key:       []byte("1:2"),
+		Value:     []byte("{"),
+		Headers:   []kafka.Header{{Key: "content-type", Value: []byte("application/json")}},
+	}
+
+	tests := []struct {
+		name       string
+		publishErr error
+	}{
+		{name: "message keeps its payload and origin"},
+		{name: "publish error", publishErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			f.publisher.EXPECT().Publish(gomock.Any(), "dead-letter-queue", gomock.Any()).DoAndReturn(
+				func(ctx context.Context, topic string, messages ...kafka.Message) error {
+					if len(messages) != 1 || string(messages[0].Value) != "{" || string(messages[0].Key) != "1:2" {
+						t.Errorf("unexpected messages %+v", messages)
+					}
+					headers := make(map[string]string)
+					for _, header := range messages[0].Headers {
+						headers[header.Key] = string(header.Value)
+					}
+					want := map[string]string{
+						"content-type":  "application/json",
+						"dlq-topic":     "answersheet-events",
+						"dlq-partition": "3",
+						"dlq-offset":    "42",
+						"dlq-error":     "bad message",
+					}
+					if !reflect.DeepEqual(headers, want) {
+						t.Errorf("headers = %v, want %v", headers, want)
+					}
+					return tt.publishErr
+				})
+			if err := f.usecase.PushToDeadLetterQueue(context.Background(), message, errors.New("bad message")); !errors.Is(err, tt.publishErr) {
+				t.Fatalf("PushToDeadLetterQueue() error = %v, want %v", err, tt.publishErr)
+			}
+		})
+	}
+}
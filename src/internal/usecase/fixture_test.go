@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/entities"
+	"picket-answersheet-service/src/internal/mocks"
+	"testing"
+	"time"
+)
+
+var errStorage = errors.New("storage down")
+
+type answersheetFixture struct {
+	repository  *mocks.MockIAnswersheetRepository
+	testUsecase *mocks.MockITestUsecase
+	publisher   *mocks.MockIPublisher
+	outbox      *mocks.MockIOutboxRepository
+	config      *mocks.MockIConfig
+	hub         *mocks.MockIHub
+	usecase     *answersheetUsecase
+
+	accommodations *mocks.MockIAccommodationUsecase
+}
+
+func newAnswersheetFixture(t *testing.T) *answersheetFixture {
+	ctrl := gomock.NewController(t)
+	f := &answersheetFixture{
+		repository:  mocks.NewMockIAnswersheetRepository(ctrl),
+		testUsecase: mocks.NewMockITestUsecase(ctrl),
+		publisher:   mocks.NewMockIPublisher(ctrl),
+		outbox:      mocks.NewMockIOutboxRepository(ctrl),
+		config:      mocks.NewMockIConfig(ctrl),
+		hub:         mocks.NewMockIHub(ctrl),
+
+		accommodations: mocks.NewMockIAccommodationUsecase(ctrl),
+	}
+	f.hub.EXPECT().Publish(gomock.Any()).AnyTimes()
+	f.config.EXPECT().GetMaxClockSkew().Return(time.Minute).AnyTimes()
+	f.usecase = NewAnswersheetUsecase(f.repository, f.config, f.testUsecase, f.publisher, f.outbox, f.hub, f.accommodations)
+	return f
+}
+
+// latestSession makes FindLatestSession answer with session, or with
+// entities.ErrNotFound when session is nil.
+func (f *answersheetFixture) latestSession(session *entities.Session) {
+	if session == nil {
+		f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(nil, entities.ErrNotFound).AnyTimes()
+		return
+	}
+	f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).DoAndReturn(func(ctx context.Context, userId int, testId int) (*entities.Session, error) {
+		copied := *session
+		copied.Answers = make(map[int]entities.SessionAnswer)
+		for key, value := range session.Answers {
+			copied.Answers[key] = value
+		}
+		return &copied, nil
+	}).AnyTimes()
+}
+
+// answerKey makes GetByTestIdAndVersion answer with a test of three single choice
+// questions and a number question 4.
+func (f *answersheetFixture) answerKey() {
+	f.testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, gomock.Any()).Return(&entities.Test{TestId: 2, Content: &entities.TestContent{MultipleChoice: &entities.TestMultipleChoice{
+		Answers: []entities.TestMultipleChoiceAnswer{
+			{Id: 1, Answer: "A", Score: 1},
+			{Id: 2, Answer: "B", Score: 1},
+			{Id: 3, Answer: "C", Score: 1},
+			{Id: 4, Answer: "42", Score: 1, Type: entities.QUESTION_NUMBER},
+		},
+	}}}, nil)
+}
+
+func (f *answersheetFixture) noAccommodation() {
+	f.accommodations.EXPECT().GetAccommodation(gomock.Any(), 2, 1).Return(nil, nil)
+}
+
+func (f *answersheetFixture) noDuplicate(key string) {
+	f.repository.EXPECT().FindByIdempotencyKey(gomock.Any(), key).Return(nil, entities.ErrNotFound)
+}
+
+func newTestSession(state string, startedAt time.Time, deadline time.Time) *entities.Session {
+	return &entities.Session{
+		SessionId:    "session-1",
+		UserId:       1,
+		TestId:       2,
+		State:        state,
+		Answers:      map[int]entities.SessionAnswer{},
+		StartedAt:    &startedAt,
+		Deadline:     &deadline,
+		StartEventId: primitive.NewObjectID(),
+		Attempt:      1,
+		Version:      1,
+	}
+}
+
+func inProgress() *entities.Session {
+	now := time.Now()
+	return newTestSession(entities.SESSION_IN_PROGRESS, now.Add(-10*time.Minute), now.Add(50*time.Minute))
+}
+
+func expired() *entities.Session {
+	now := time.Now()
+	return newTestSession(entities.SESSION_IN_PROGRESS, now.Add(-2*time.Hour), now.Add(-time.Hour))
+}
+
+func withState(session *entities.Session, state string) *entities.Session {
+	session.State = state
+	return session
+}
+
+func submittedAt(endedAt time.Time) *entities.Session {
+	session := withState(inProgress(), entities.SESSION_SUBMITTED)
+	session.EndedAt = &endedAt
+	return session
+}
+
+func pausedFor(d time.Duration) *entities.Session {
+	session := withState(inProgress(), entities.SESSION_PAUSED)
+	pausedAt := time.Now().Add(-d)
+	session.PausedAt = &pausedAt
+	return session
+}
+
+// checkErr fails t unless err is wantErr, or matches wantAs when it is set. It
+// reports whether the call succeeded, so its result is worth checking.
+func checkErr(t *testing.T, err error, wantErr error, wantAs interface{}) bool {
+	t.Helper()
+	if wantAs != nil {
+		if !errors.As(err, wantAs) {
+			t.Fatalf("error = %v, want %T", err, wantAs)
+		}
+		return false
+	}
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("error = %v, want %v", err, wantErr)
+	}
+	return err == nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+// FlagQuestion marks a question of the latest session of the user for review.
+// Flags are kept apart from the answers and never count in the score.
+func (u *answersheetUsecase) FlagQuestion(ctx context.Context, input dto.FlagQuestionInput) (*entities.Event, error) {
+	input.Payload.Event = entities.FLAG
+	return u.flagOrUnflag(ctx, input)
+}
+
+// UnflagQuestion clears the review mark of a question.
+func (u *answersheetUsecase) UnflagQuestion(ctx context.Context, input dto.FlagQuestionInput) (*entities.Event, error) {
+	input.Payload.Event = entities.UNFLAG
+	return u.flagOrUnflag(ctx, input)
+}
+
+func (u *answersheetUsecase) flagOrUnflag(ctx context.Context, input dto.FlagQuestionInput) (*entities.Event, error) {
+	if err := validateStruct(input); err != nil {
+		return nil, err
+	}
+	duplicate, err := u.findDuplicate(ctx, input.IdempotencyKey)
+	if err != nil || duplicate != nil {
+		return duplicate, err
+	}
+	session, err := u.loadSession(ctx, input.Payload.UserId, input.Payload.TestId)
+	if err != nil {
+		return nil, err
+	}
+	if session.State == entities.SESSION_NOT_STARTED {
+		return nil, ErrSessionNotStarted
+	}
+	if err := session.Can(input.Payload.Event, time.Now()); err != nil {
+		return nil, err
+	}
+	test, err := u.sessionTest(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateQuestion(input.Payload.QuestionId, test); err != nil {
+		return nil, err
+	}
+
+	e := entities.Event{
+		Id:             primitive.NewObjectID(),
+		UserId:         session.UserId,
+		TestId:         session.TestId,
+		Event:          input.Payload.Event,
+		Session:        session.SessionId,
+		QuestionId:     input.Payload.QuestionId,
+		IdempotencyKey: input.IdempotencyKey,
+	}
+	u.stamp(&e, input.Payload.CreatedAt)
+
+	expected := session.Version
+	if err := session.Apply(&e); err != nil {
+		return nil, err
+	}
+
+	outbox, err := newJobOutbox(input.JobId)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repository.Create(ctx, &e, session, expected, outbox); err != nil {
+		log.Error().Err(err).Str("session", session.SessionId).Send()
+		return nil, err
+	}
+	u.notify(entities.UPDATE_FLAG, session, nil)
+
+	return &e, nil
+}
+
+// GetSubmitSummary lists the questions of the latest session of the user left
+// unanswered and the ones flagged for review, so the user can be warned before
+// submitting.
+func (u *answersheetUsecase) GetSubmitSummary(ctx context.Context, userId int, testId int) (*dto.SubmitSummaryOutput, error) {
+	session, err := u.loadSession(ctx, userId, testId)
+	if err != nil {
+		return nil, err
+	}
+	if session.State == entities.SESSION_NOT_STARTED {
+		return nil, ErrSessionNotStarted
+	}
+	order, err := u.questionOrder(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	remaining, _ := session.Remaining(now)
+	result := &dto.SubmitSummaryOutput{
+		Session:    session,
+		State:      session.StateAt(now),
+		Total:      len(order),
+		Unanswered: make([]int, 0),
+		Flagged:    make([]int, 0, len(session.Flagged)),
+		Remaining:  remaining,
+	}
+	flagged := make(map[int]bool, len(session.Flagged))
+	for _, questionId := range session.Flagged {
+		flagged[questionId] = true
+	}
+	for _, questionId := range order {
+		if _, ok := session.Answers[questionId]; ok {
+			result.Answered++
+		} else {
+			result.Unanswered = append(result.Unanswered, questionId)
+		}
+		if flagged[questionId] {
+			result.Flagged = append(result.Flagged, questionId)
+			delete(flagged, questionId)
+		}
+	}
+	// flags of questions the test no longer lists
+	for _, questionId := range session.Flagged {
+		if flagged[questionId] {
+			result.Flagged = append(result.Flagged, questionId)
+		}
+	}
+	return result, nil
+}
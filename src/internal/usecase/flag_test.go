@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func flagInput(questionId int) dto.FlagQuestionInput {
+	var input dto.FlagQuestionInput
+	input.JobId = 14
+	input.IdempotencyKey = "flag-1"
+	input.Payload.UserId = 1
+	input.Payload.TestId = 2
+	input.Payload.QuestionId = questionId
+	return input
+}
+
+func flagged(session *entities.Session, questionIds ...int) *entities.Session {
+	session.Flagged = questionIds
+	return session
+}
+
+func TestAnswersheetUsecase_FlagQuestion(t *testing.T) {
+	var transitionErr *entities.TransitionError
+	var validationErr *ValidationError
+	tests := []struct {
+		name        string
+		action      func(u *answersheetUsecase, ctx context.Context, input dto.FlagQuestionInput) (*entities.Event, error)
+		input       dto.FlagQuestionInput
+		setup       func(f *answersheetFixture)
+		wantFlagged []int
+		wantErr     error
+		wantAs      interface{}
+	}{
+		{
+			name:   "flag keeps the questions in order",
+			action: (*answersheetUsecase).FlagQuestion,
+			input:  flagInput(2),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("flag-1")
+				f.latestSession(flagged(inProgress(), 1, 3))
+				f.answerKey()
+			},
+			wantFlagged: []int{1, 2, 3},
+		},
+		{
+			name:   "flag of a flagged question",
+			action: (*answersheetUsecase).FlagQuestion,
+			input:  flagInput(3),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("flag-1")
+				f.latestSession(flagged(inProgress(), 1, 3))
+				f.answerKey()
+			},
+			wantFlagged: []int{1, 3},
+		},
+		{
+			name:   "unflag clears the mark",
+			action: (*answersheetUsecase).UnflagQuestion,
+			input:  flagInput(1),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("flag-1")
+				f.latestSession(flagged(inProgress(), 1, 3))
+				f.answerKey()
+			},
+			wantFlagged: []int{3},
+		},
+		{
+			name:   "question not in the test",
+			action: (*answersheetUsecase).FlagQuestion,
+			input:  flagInput(9),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("flag-1")
+				f.latestSession(inProgress())
+				f.answerKey()
+			},
+			wantAs: &validationErr,
+		},
+		{
+			name:   "question is required",
+			action: (*answersheetUsecase).FlagQuestion,
+			input:  flagInput(0),
+			setup:  func(f *answersheetFixture) {},
+			wantAs: &validationErr,
+		},
+		{
+			name:   "flag while paused",
+			action: (*answersheetUsecase).FlagQuestion,
+			input:  flagInput(1),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("flag-1")
+				f.latestSession(pausedFor(time.Minute))
+			},
+			wantAs: &transitionErr,
+		},
+		{
+			name:   "flag after submit",
+			action: (*answersheetUsecase).FlagQuestion,
+			input:  flagInput(1),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("flag-1")
+				f.latestSession(submittedAt(time.Now()))
+			},
+			wantAs: &transitionErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			tt.setup(f)
+			if tt.wantFlagged != nil {
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 1, gomock.Not(gomock.Nil())).DoAndReturn(
+					func(ctx context.Context, event *entities.Event, session *entities.Session, expectedVersion int, outbox *entities.Outbox) error {
+						if event.QuestionId != tt.input.Payload.QuestionId || event.Answer != "" {
+							t.Errorf("unexpected event %+v", event)
+						}
+						if !reflect.DeepEqual(session.Flagged, tt.wantFlagged) || len(session.Answers) != 0 {
+							t.Errorf("flagged %v, want %v", session.Flagged, tt.wantFlagged)
+						}
+						return nil
+					})
+			}
+			_, err := tt.action(f.usecase, context.Background(), tt.input)
+			checkErr(t, err, tt.wantErr, tt.wantAs)
+		})
+	}
+}
+
+func TestAnswersheetUsecase_GetSubmitSummary(t *testing.T) {
+	answered := func(session *entities.Session) *entities.Session {
+		session.Answers[2] = entities.SessionAnswer{QuestionId: 2, Answer: "B"}
+		return session
+	}
+	shuffled := answered(flagged(inProgress(), 1, 2))
+	shuffled.Shuffle = &entities.Shuffle{Questions: []int{4, 2, 1, 3}}
+
+	tests := []struct {
+		name    string
+		setup   func(f *answersheetFixture)
+		want    *dto.SubmitSummaryOutput
+		wantErr error
+	}{
+		{
+			name: "unanswered and flagged questions",
+			setup: func(f *answersheetFixture) {
+				f.latestSession(answered(flagged(inProgress(), 2, 3)))
+				f.answerKey()
+			},
+			want: &dto.SubmitSummaryOutput{Total: 4, Answered: 1, Unanswered: []int{1, 3, 4}, Flagged: []int{2, 3}},
+		},
+		{
+			name: "order of a shuffled session",
+			setup: func(f *answersheetFixture) {
+				f.latestSession(shuffled)
+			},
+			want: &dto.SubmitSummaryOutput{Total: 4, Answered: 1, Unanswered: []int{4, 1, 3}, Flagged: []int{2, 1}},
+		},
+		{
+			name: "test not synced",
+			setup: func(f *answersheetFixture) {
+				f.latestSession(flagged(inProgress(), 5))
+				f.testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, gomock.Any()).Return(nil, entities.ErrNotFound)
+			},
+			want: &dto.SubmitSummaryOutput{Unanswered: []int{}, Flagged: []int{5}},
+		},
+		{
+			name: "never started",
+			setup: func(f *answersheetFixture) {
+				f.latestSession(nil)
+			},
+			wantErr: ErrSessionNotStarted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			tt.setup(f)
+			got, err := f.usecase.GetSubmitSummary(context.Background(), 1, 2)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSubmitSummary() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				return
+			}
+			if got.Total != tt.want.Total || got.Answered != tt.want.Answered ||
+				!reflect.DeepEqual(got.Unanswered, tt.want.Unanswered) || !reflect.DeepEqual(got.Flagged, tt.want.Flagged) {
+				t.Fatalf("GetSubmitSummary() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
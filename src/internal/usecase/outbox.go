@@ -0,0 +1,119 @@
+package usecase
+
+//go:generate mockgen -source=outbox.go -destination=../mocks/outbox.go -package=mocks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+const outboxLease = 30 * time.Second
+
+type IOutboxRepository interface {
+	Create(ctx context.Context, outbox *entities.Outbox) error
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]entities.Outbox, error)
+	MarkSent(ctx context.Context, id primitive.ObjectID) error
+	Release(ctx context.Context, id primitive.ObjectID, errMessage string) error
+}
+
+type outboxUsecase struct {
+	repository IOutboxRepository
+	publisher  IPublisher
+}
+
+func NewOutboxUsecase(repository IOutboxRepository, publisher IPublisher) *outboxUsecase {
+	return &outboxUsecase{repository: repository, publisher: publisher}
+}
+
+// Relay publishes one batch of pending outbox rows and returns how many rows
+// were claimed. The outbox id is sent as the message key so consumers can drop
+// the rare duplicate produced when a row is published but not yet marked sent.
+func (u *outboxUsecase) Relay(ctx context.Context, limit int) (int, error) {
+	list, err := u.repository.ClaimPending(ctx, limit, outboxLease)
+	if err != nil {
+		log.Error().Err(err).Send()
+		return 0, err
+	}
+
+	byTopic := make(map[string][]entities.Outbox)
+	for _, item := range list {
+		byTopic[item.Topic] = append(byTopic[item.Topic], item)
+	}
+
+	for topic, items := range byTopic {
+		messages := make([]kafka.Message, len(items))
+		for index, item := range items {
+			messages[index] = kafka.Message{
+				Key:   []byte(item.Id.Hex()),
+				Value: item.Payload,
+			}
+		}
+
+		if err := u.publisher.Publish(ctx, topic, messages...); err != nil {
+			log.Error().Err(err).Str("topic", topic).Msg("relay outbox")
+			for _, item := range items {
+				if err := u.repository.Release(ctx, item.Id, err.Error()); err != nil {
+					log.Error().Err(err).Str("outbox_id", item.Id.Hex()).Send()
+				}
+			}
+			continue
+		}
+
+		for _, item := range items {
+			if err := u.repository.MarkSent(ctx, item.Id); err != nil {
+				log.Error().Err(err).Str("outbox_id", item.Id.Hex()).Send()
+			}
+		}
+	}
+
+	return len(list), nil
+}
+
+func newOutbox(topic string, payload map[string]interface{}) (*entities.Outbox, error) {
+	b := new(bytes.Buffer)
+	if err := json.NewEncoder(b).Encode(payload); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &entities.Outbox{
+		Id:        primitive.NewObjectID(),
+		Topic:     topic,
+		Payload:   b.Bytes(),
+		Status:    entities.OUTBOX_PENDING,
+		CreatedAt: &now,
+	}, nil
+}
+
+func newJobSuccessOutbox(jobId int) (*entities.Outbox, error) {
+	return newOutbox("job-success", map[string]interface{}{
+		"job_id": jobId,
+	})
+}
+
+// newJobOutbox is the job-success row written with an event, none for events
+// that did not come from a job, like those sent on AnswerStream.
+func newJobOutbox(jobId int) (*entities.Outbox, error) {
+	if jobId == 0 {
+		return nil, nil
+	}
+	return newJobSuccessOutbox(jobId)
+}
+
+func newJobFailOutbox(jobId int, errFail error) (*entities.Outbox, error) {
+	payload := map[string]interface{}{
+		"job_id":        jobId,
+		"error_message": errFail.Error(),
+	}
+	var validationErr *ValidationError
+	if errors.As(errFail, &validationErr) {
+		payload["errors"] = validationErr.Fields
+	}
+	return newOutbox("job-fail", payload)
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/entities"
+	"picket-answersheet-service/src/internal/mocks"
+	"testing"
+)
+
+func TestOutboxUsecase_Relay(t *testing.T) {
+	success := entities.Outbox{Id: primitive.NewObjectID(), Topic: "job-success", Payload: []byte(`{"job_id":1}`)}
+	fail := entities.Outbox{Id: primitive.NewObjectID(), Topic: "job-fail", Payload: []byte(`{"job_id":2}`)}
+
+	tests := []struct {
+		name    string
+		setup   func(repository *mocks.MockIOutboxRepository, publisher *mocks.MockIPublisher)
+		want    int
+		wantErr error
+	}{
+		{
+			name: "published rows are marked sent",
+			setup: func(repository *mocks.MockIOutboxRepository, publisher *mocks.MockIPublisher) {
+				repository.EXPECT().ClaimPending(gomock.Any(), 10, outboxLease).Return([]entities.Outbox{success, fail}, nil)
+				publisher.EXPECT().Publish(gomock.Any(), "job-success", gomock.Any()).DoAndReturn(
+					func(ctx context.Context, topic string, messages ...kafka.Message) error {
+						if len(messages) != 1 || string(messages[0].Key) != success.Id.Hex() {
+							t.Errorf("unexpected messages %+v", messages)
+						}
+						return nil
+					})
+				publisher.EXPECT().Publish(gomock.Any(), "job-fail", gomock.Any()).Return(nil)
+				repository.EXPECT().MarkSent(gomock.Any(), success.Id).Return(nil)
+				repository.EXPECT().MarkSent(gomock.Any(), fail.Id).Return(nil)
+			},
+			want: 2,
+		},
+		{
+			name: "rows of a failed topic are released",
+			setup: func(repository *mocks.MockIOutboxRepository, publisher *mocks.MockIPublisher) {
+				repository.EXPECT().ClaimPending(gomock.Any(), 10, outboxLease).Return([]entities.Outbox{success, fail}, nil)
+				publisher.EXPECT().Publish(gomock.Any(), "job-success", gomock.Any()).Return(errStorage)
+				publisher.EXPECT().Publish(gomock.Any(), "job-fail", gomock.Any()).Return(nil)
+				repository.EXPECT().Release(gomock.Any(), success.Id, errStorage.Error()).Return(nil)
+				repository.EXPECT().MarkSent(gomock.Any(), fail.Id).Return(nil)
+			},
+			want: 2,
+		},
+		{
+			name: "nothing pending",
+			setup: func(repository *mocks.MockIOutboxRepository, publisher *mocks.MockIPublisher) {
+				repository.EXPECT().ClaimPending(gomock.Any(), 10, outboxLease).Return(nil, nil)
+			},
+		},
+		{
+			name: "claim error",
+			setup: func(repository *mocks.MockIOutboxRepository, publisher *mocks.MockIPublisher) {
+				repository.EXPECT().ClaimPending(gomock.Any(), 10, outboxLease).Return(nil, errStorage)
+			},
+			wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repository := mocks.NewMockIOutboxRepository(ctrl)
+			publisher := mocks.NewMockIPublisher(ctrl)
+			tt.setup(repository, publisher)
+			got, err := NewOutboxUsecase(repository, publisher).Relay(context.Background(), 10)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Relay() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Relay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+// ErrNoDeadline means the session runs without a time limit, there is nothing
+// to extend.
+var ErrNoDeadline = errors.New("session has no deadline")
+
+// ExtendTime pushes back the deadline of the latest session of the user at the
+// test by input.Minutes.
+func (u *answersheetUsecase) ExtendTime(ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error) {
+	if input.Minutes <= 0 {
+		return nil, nil, &ValidationError{Fields: []FieldError{{Field: "minutes", Message: "failed gt=0"}}}
+	}
+	return u.proctorAction(ctx, input, entities.EXTEND, entities.UPDATE_EXTEND, func(session *entities.Session) error {
+		if session.Deadline == nil {
+			return ErrNoDeadline
+		}
+		return nil
+	})
+}
+
+// ForceSubmit writes the END of the latest session of the user at the test on
+// behalf of the user.
+func (u *answersheetUsecase) ForceSubmit(ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error) {
+	return u.proctorAction(ctx, input, entities.END, entities.UPDATE_FORCE_SUBMIT, nil)
+}
+
+// VoidSession cancels the latest session of the user at the test, submitted
+// or not; it is no longer scored and the user may start again.
+func (u *answersheetUsecase) VoidSession(ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error) {
+	return u.proctorAction(ctx, input, entities.VOID, entities.UPDATE_STATE, nil)
+}
+
+// ProctorPause stops the clock of the latest session of the user at the test,
+// it refuses answers until the session is resumed.
+func (u *answersheetUsecase) ProctorPause(ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error) {
+	return u.proctorAction(ctx, input, entities.PAUSE, entities.UPDATE_STATE, nil)
+}
+
+// ProctorResume restarts the clock of the paused session of the user at the
+// test, its deadline is pushed back by the time it spent paused.
+func (u *answersheetUsecase) ProctorResume(ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error) {
+	return u.proctorAction(ctx, input, entities.RESUME, entities.UPDATE_STATE, nil)
+}
+
+// proctorAction stores eventType on the latest session of the user at the test
+// with the proctor and the reason, and tells the watchers with updateType.
+func (u *answersheetUsecase) proctorAction(ctx context.Context, input dto.ProctorActionInput, eventType string, updateType string, check func(session *entities.Session) error) (*entities.Event, *entities.Session, error) {
+	if err := validateStruct(input); err != nil {
+		return nil, nil, err
+	}
+	duplicate, err := u.findDuplicate(ctx, input.IdempotencyKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if duplicate != nil {
+		session, err := u.loadSession(ctx, input.UserId, input.TestId)
+		return duplicate, session, err
+	}
+
+	session, err := u.loadSession(ctx, input.UserId, input.TestId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if session.State == entities.SESSION_NOT_STARTED {
+		return nil, nil, ErrSessionNotStarted
+	}
+	now := time.Now()
+	if err := session.Can(eventType, now); err != nil {
+		return nil, nil, err
+	}
+	if check != nil {
+		if err := check(session); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	e := entities.Event{
+		Id:             primitive.NewObjectID(),
+		UserId:         session.UserId,
+		TestId:         session.TestId,
+		Event:          eventType,
+		Session:        session.SessionId,
+		Reason:         input.Reason,
+		ActorId:        input.ProctorId,
+		IdempotencyKey: input.IdempotencyKey,
+	}
+	if eventType == entities.EXTEND {
+		e.Extension = time.Duration(input.Minutes) * time.Minute
+	}
+	e.Stamp(now, nil, 0)
+
+	expected := session.Version
+	if err := session.Apply(&e); err != nil {
+		return nil, nil, err
+	}
+	if err := u.repository.Create(ctx, &e, session, expected, nil); err != nil {
+		log.Error().Err(err).Str("session", session.SessionId).Send()
+		return nil, nil, err
+	}
+
+	log.Info().Int("proctor_id", input.ProctorId).Int("user_id", session.UserId).Int("test_id", session.TestId).
+		Str("session", session.SessionId).Str("event", eventType).Str("reason", input.Reason).Msg("proctor action")
+	u.notify(updateType, session, nil)
+	return &e, session, nil
+}
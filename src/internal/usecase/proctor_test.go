@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"testing"
+	"time"
+)
+
+func proctorInput(minutes int) dto.ProctorActionInput {
+	return dto.ProctorActionInput{ProctorId: 9, UserId: 1, TestId: 2, Minutes: minutes, Reason: "fire drill", IdempotencyKey: "proctor-1"}
+}
+
+func TestAnswersheetUsecase_ProctorActions(t *testing.T) {
+	var transitionErr *entities.TransitionError
+	var validationErr *ValidationError
+	tests := []struct {
+		name    string
+		action  func(u *answersheetUsecase, ctx context.Context, input dto.ProctorActionInput) (*entities.Event, *entities.Session, error)
+		input   dto.ProctorActionInput
+		setup   func(f *answersheetFixture)
+		check   func(t *testing.T, event *entities.Event, session *entities.Session)
+		wantErr error
+		wantAs  interface{}
+	}{
+		{
+			name:   "extend pushes back the deadline and records the proctor",
+			action: (*answersheetUsecase).ExtendTime,
+			input:  proctorInput(15),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("proctor-1")
+				f.latestSession(inProgress())
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 1, nil).Return(nil)
+			},
+			check: func(t *testing.T, event *entities.Event, session *entities.Session) {
+				if event.Event != entities.EXTEND || event.ActorId != 9 || event.Reason != "fire drill" || event.Extension != 15*time.Minute {
+					t.Errorf("unexpected event %+v", event)
+				}
+				if remaining, _ := session.Remaining(time.Now()); remaining < 64*time.Minute || session.Extension != 15*time.Minute {
+					t.Errorf("remaining %v, extension %v", remaining, session.Extension)
+				}
+			},
+		},
+		{
+			name:   "extend of an expired session reopens it",
+			action: (*answersheetUsecase).ExtendTime,
+			input:  proctorInput(120),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("proctor-1")
+				f.latestSession(expired())
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, event *entities.Event, session *entities.Session) {
+				if !session.IsActive(time.Now()) {
+					t.Errorf("session is %s", session.StateAt(time.Now()))
+				}
+			},
+		},
+		{
+			name:   "extend needs minutes",
+			action: (*answersheetUsecase).ExtendTime,
+			input:  proctorInput(0),
+			setup:  func(f *answersheetFixture) {},
+			wantAs: &validationErr,
+		},
+		{
+			name:   "extend of a session without deadline",
+			action: (*answersheetUsecase).ExtendTime,
+			input:  proctorInput(15),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("proctor-1")
+				session := inProgress()
+				session.Deadline = nil
+				f.latestSession(session)
+			},
+			wantErr: ErrNoDeadline,
+		},
+		{
+			name:   "extend of a submitted session",
+			action: (*answersheetUsecase).ExtendTime,
+			input:  proctorInput(15),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("proctor-1")
+				f.latestSession(submittedAt(time.Now()))
+			},
+			wantAs: &transitionErr,
+		},
+		{
+			name:   "force submit writes the END",
+			action: (*answersheetUsecase).ForceSubmit,
+			input:  proctorInput(0),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("proctor-1")
+				f.latestSession(inProgress())
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 1, nil).Return(nil)
+			},
+			check: func(t *testing.T, event *entities.Event, session *entities.Session) {
+				if event.Event != entities.END || event.ActorId != 9 || session.State != entities.SESSION_SUBMITTED {
+					t.Errorf("unexpected event %+v session %+v", event, session)
+				}
+			},
+		},
+		{
+			name:   "void of a submitted session",
+			action: (*answersheetUsecase).VoidSession,
+			input:  proctorInput(0),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("proctor-1")
+				f.latestSession(submittedAt(time.Now()))
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, event *entities.Event, session *entities.Session) {
+				if event.Event != entities.VOID || session.State != entities.SESSION_VOIDED {
+					t.Errorf("unexpected event %+v session %+v", event, session)
+				}
+			},
+		},
+		{
+			name:   "pause stops the clock",
+			action: (*answersheetUsecase).ProctorPause,
+			input:  proctorInput(0),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("proctor-1")
+				f.latestSession(inProgress())
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 1, nil).Return(nil)
+			},
+			check: func(t *testing.T, event *entities.Event, session *entities.Session) {
+				if event.Event != entities.PAUSE || event.ActorId != 9 || session.State != entities.SESSION_PAUSED || session.PausedAt == nil {
+					t.Errorf("unexpected event %+v session %+v", event, session)
+				}
+				before, _ := session.Remaining(time.Now())
+				after, _ := session.Remaining(time.Now().Add(time.Hour))
+				if before != after {
+					t.Errorf("remaining ran down from %v to %v while paused", before, after)
+				}
+			},
+		},
+		{
+			name:   "resume pushes back the deadline by the paused time",
+			action: (*answersheetUsecase).ProctorResume,
+			input:  proctorInput(0),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("proctor-1")
+				f.latestSession(pausedFor(20 * time.Minute))
+				f.repository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), 1, nil).Return(nil)
+			},
+			check: func(t *testing.T, event *entities.Event, session *entities.Session) {
+				if session.State != entities.SESSION_IN_PROGRESS || session.PausedAt != nil || session.Paused < 20*time.Minute {
+					t.Errorf("unexpected session %+v", session)
+				}
+				if remaining, _ := session.Remaining(time.Now()); remaining < 69*time.Minute {
+					t.Errorf("remaining %v, want about 70m", remaining)
+				}
+			},
+		},
+		{
+			name:   "pause of a paused session",
+			action: (*answersheetUsecase).ProctorPause,
+			input:  proctorInput(0),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("proctor-1")
+				f.latestSession(pausedFor(time.Minute))
+			},
+			wantAs: &transitionErr,
+		},
+		{
+			name:   "resume of a session not paused",
+			action: (*answersheetUsecase).ProctorResume,
+			input:  proctorInput(0),
+			setup: func(f *answersheetFixture) {
+				f.noDuplicate("proctor-1")
+				f.latestSession(inProgress())
+			},
+			wantAs: &transitionErr,
+		},
+		{
+			name:   "proctor is required",
+			action: (*answersheetUsecase).ForceSubmit,
+			input:  dto.ProctorActionInput{UserId: 1, TestId: 2},
+			setup:  func(f *answersheetFixture) {},
+			wantAs: &validationErr,
+		},
+		{
+			name:   "duplicate returns the stored event",
+			action: (*answersheetUsecase).ForceSubmit,
+			input:  proctorInput(0),
+			setup: func(f *answersheetFixture) {
+				f.repository.EXPECT().FindByIdempotencyKey(gomock.Any(), "proctor-1").Return(&entities.Event{Event: entities.END}, nil)
+				f.latestSession(submittedAt(time.Now()))
+			},
+			check: func(t *testing.T, event *entities.Event, session *entities.Session) {
+				if event.Event != entities.END || session.State != entities.SESSION_SUBMITTED {
+					t.Errorf("unexpected event %+v session %+v", event, session)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			tt.setup(f)
+			event, session, err := tt.action(f.usecase, context.Background(), tt.input)
+			if checkErr(t, err, tt.wantErr, tt.wantAs) && tt.check != nil {
+				tt.check(t, event, session)
+			}
+		})
+	}
+}
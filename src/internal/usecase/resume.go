@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+// ResumeSession returns the latest session of the user at the test with its
+// answers, the order of its questions and the time left, so a client can pick
+// it up after a crash or on another device.
+func (u *answersheetUsecase) ResumeSession(ctx context.Context, userId int, testId int) (*dto.ResumeSessionOutput, error) {
+	session, err := u.loadSession(ctx, userId, testId)
+	if err != nil {
+		return nil, err
+	}
+	if session.State == entities.SESSION_NOT_STARTED {
+		return nil, ErrSessionNotStarted
+	}
+	order, err := u.questionOrder(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	remaining, _ := session.Remaining(now)
+	result := &dto.ResumeSessionOutput{
+		Session:       session,
+		State:         session.StateAt(now),
+		Answers:       session.SortedAnswers(),
+		Flagged:       session.Flagged,
+		QuestionOrder: order,
+		Remaining:     remaining,
+		ServerTime:    now,
+	}
+	if session.Shuffle != nil {
+		result.Options = session.Shuffle.Options
+	}
+	return result, nil
+}
+
+// questionOrder is the order the questions of the session are shown in, the
+// one of the answer key when they are not shuffled.
+func (u *answersheetUsecase) questionOrder(ctx context.Context, session *entities.Session) ([]int, error) {
+	if session.Shuffle != nil {
+		return session.Shuffle.QuestionOrder(), nil
+	}
+	test, err := u.sessionTest(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return test.QuestionIds(), nil
+}
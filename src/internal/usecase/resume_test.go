@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnswersheetUsecase_ResumeSession(t *testing.T) {
+	answeredAt := time.Now().Add(-5 * time.Minute)
+	answered := func(session *entities.Session) *entities.Session {
+		session.TestVersion = 3
+		session.Answers[3] = entities.SessionAnswer{QuestionId: 3, Answer: "C", AnsweredAt: &answeredAt, EventId: primitive.NewObjectID()}
+		session.Answers[1] = entities.SessionAnswer{QuestionId: 1, Answer: "A", AnsweredAt: &answeredAt, EventId: primitive.NewObjectID()}
+		return session
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(f *answersheetFixture)
+		check   func(t *testing.T, got *dto.ResumeSessionOutput)
+		wantErr error
+	}{
+		{
+			name: "answers, question order and time left",
+			setup: func(f *answersheetFixture) {
+				f.latestSession(answered(inProgress()))
+				f.answerKey()
+			},
+			check: func(t *testing.T, got *dto.ResumeSessionOutput) {
+				if got.Session.SessionId != "session-1" || got.Session.TestVersion != 3 || got.State != entities.SESSION_IN_PROGRESS {
+					t.Errorf("unexpected session %+v", got.Session)
+				}
+				if len(got.Answers) != 2 || got.Answers[0].QuestionId != 1 || got.Answers[1].QuestionId != 3 || got.Answers[0].AnsweredAt == nil {
+					t.Errorf("answers = %+v", got.Answers)
+				}
+				if !reflect.DeepEqual(got.QuestionOrder, []int{1, 2, 3, 4}) {
+					t.Errorf("question order = %v", got.QuestionOrder)
+				}
+				if got.Remaining < 49*time.Minute || got.Remaining > 50*time.Minute || got.ServerTime.IsZero() {
+					t.Errorf("remaining %v at %v", got.Remaining, got.ServerTime)
+				}
+			},
+		},
+		{
+			name: "time left of a paused session is frozen",
+			setup: func(f *answersheetFixture) {
+				f.latestSession(pausedFor(30 * time.Minute))
+				f.answerKey()
+			},
+			check: func(t *testing.T, got *dto.ResumeSessionOutput) {
+				if got.State != entities.SESSION_PAUSED || got.Remaining < 79*time.Minute {
+					t.Errorf("state %s remaining %v", got.State, got.Remaining)
+				}
+			},
+		},
+		{
+			name: "submitted session is still returned",
+			setup: func(f *answersheetFixture) {
+				f.latestSession(answered(submittedAt(time.Now())))
+				f.testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, gomock.Any()).Return(nil, entities.ErrNotFound)
+			},
+			check: func(t *testing.T, got *dto.ResumeSessionOutput) {
+				if got.State != entities.SESSION_SUBMITTED || len(got.Answers) != 2 || got.QuestionOrder != nil {
+					t.Errorf("unexpected output %+v", got)
+				}
+			},
+		},
+		{
+			name: "never started",
+			setup: func(f *answersheetFixture) {
+				f.latestSession(nil)
+			},
+			wantErr: ErrSessionNotStarted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAnswersheetFixture(t)
+			tt.setup(f)
+			got, err := f.usecase.ResumeSession(context.Background(), 1, 2)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResumeSession() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
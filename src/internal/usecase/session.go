@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"picket-answersheet-service/src/internal/entities"
+	"sort"
+)
+
+type sessionUsecase struct {
+	repository  IAnswersheetRepository
+	testUsecase ITestUsecase
+}
+
+func NewSessionUsecase(repository IAnswersheetRepository, testUsecase ITestUsecase) *sessionUsecase {
+	return &sessionUsecase{repository: repository, testUsecase: testUsecase}
+}
+
+// rebuildAttempts bounds how often a session is replayed again when a write
+// lands on it while it is being rebuilt.
+const rebuildAttempts = 3
+
+type replayedSession struct {
+	session *entities.Session
+	stored  *entities.Session
+}
+
+// Rebuild replays the event log of every session and writes the result to the
+// sessions read model. Attempts are numbered again per user and test in the
+// order the sessions started. Deadlines come from the test version the session
+// started with and the shuffle is the one it was given, never a new one. A
+// session is only written while it is at the
+// version read before its events, so consumers may keep writing during a
+// rebuild. It returns how many sessions were written.
+func (u *sessionUsecase) Rebuild(ctx context.Context) (int, error) {
+	ids, err := u.repository.ListSessionIds(ctx)
+	if err != nil {
+		log.Error().Err(err).Send()
+		return 0, err
+	}
+
+	replays := make([]replayedSession, 0, len(ids))
+	for _, id := range ids {
+		replay, err := u.replay(ctx, id)
+		if err != nil {
+			log.Error().Err(err).Str("session", id).Send()
+			return 0, err
+		}
+		if replay.session.State == entities.SESSION_NOT_STARTED {
+			continue
+		}
+		replays = append(replays, replay)
+	}
+	sort.SliceStable(replays, func(i, j int) bool {
+		return bytes.Compare(replays[i].session.StartEventId[:], replays[j].session.StartEventId[:]) < 0
+	})
+
+	type key struct{ userId, testId int }
+	type version struct{ testId, version int }
+	attempts := make(map[key]int)
+	tests := make(map[version]*entities.Test)
+	count := 0
+	for _, replay := range replays {
+		id := replay.session.SessionId
+		attempts[key{replay.session.UserId, replay.session.TestId}]++
+		attempt := attempts[key{replay.session.UserId, replay.session.TestId}]
+
+		for try := 1; ; try++ {
+			session := replay.session
+			session.Attempt = attempt
+
+			pinned := version{session.TestId, session.TestVersion}
+			test, ok := tests[pinned]
+			if !ok {
+				test, err = u.testUsecase.GetByTestIdAndVersion(ctx, session.TestId, session.TestVersion)
+				if err != nil && !errors.Is(err, entities.ErrNotFound) {
+					return count, err
+				}
+				tests[pinned] = test
+			}
+			session.SetDeadline(test)
+			if session.Shuffle == nil && replay.stored != nil {
+				// started before the shuffle was kept on the START event
+				session.Shuffle = replay.stored.Shuffle
+			}
+
+			expected := 0
+			if replay.stored != nil {
+				expected = replay.stored.Version
+			}
+			err := u.repository.SaveSession(ctx, session, expected)
+			if err == nil {
+				break
+			}
+			if !errors.Is(err, entities.ErrVersionConflict) || try == rebuildAttempts {
+				log.Error().Err(err).Str("session", id).Send()
+				return count, err
+			}
+			// written since it was read, replay it again
+			if replay, err = u.replay(ctx, id); err != nil {
+				log.Error().Err(err).Str("session", id).Send()
+				return count, err
+			}
+		}
+		count++
+	}
+	return count, nil
+}
+
+// replay reads the stored session before the events, so a write landing in
+// between makes the save conflict instead of being overwritten.
+func (u *sessionUsecase) replay(ctx context.Context, id string) (replayedSession, error) {
+	stored, err := u.repository.FindSession(ctx, id)
+	if errors.Is(err, entities.ErrNotFound) {
+		stored = nil
+	} else if err != nil {
+		return replayedSession{}, err
+	}
+
+	events, err := u.repository.FindBySession(ctx, id)
+	if err != nil {
+		return replayedSession{}, err
+	}
+	return replayedSession{session: entities.RebuildSession(events), stored: stored}, nil
+}
@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"picket-answersheet-service/src/internal/entities"
+	"picket-answersheet-service/src/internal/mocks"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSessionUsecase_Rebuild(t *testing.T) {
+	now := time.Now()
+	events := []entities.Event{
+		{Id: primitive.NewObjectID(), UserId: 1, TestId: 2, Event: entities.START, Session: "session-1", TestVersion: 3, CreatedAt: &now},
+		{Id: primitive.NewObjectID(), UserId: 1, TestId: 2, Event: entities.ANSWER, Session: "session-1", QuestionId: 1, Answer: "A", CreatedAt: &now},
+		{Id: primitive.NewObjectID(), UserId: 1, TestId: 2, Event: entities.ANSWER, Session: "session-1", QuestionId: 1, Answer: "B", CreatedAt: &now},
+	}
+	retake := []entities.Event{
+		{Id: primitive.NewObjectID(), UserId: 1, TestId: 2, Event: entities.START, Session: "session-2", TestVersion: 3, CreatedAt: &now},
+	}
+	// a test the session must not be shuffled against again
+	shuffledTest := &entities.Test{TestId: 2, Version: 3, Content: &entities.TestContent{MultipleChoice: &entities.TestMultipleChoice{
+		Answers: []entities.TestMultipleChoiceAnswer{{Id: 1, Answer: "A"}, {Id: 2, Answer: "B"}},
+	}}}
+
+	tests := []struct {
+		name    string
+		setup   func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase)
+		want    int
+		wantErr error
+	}{
+		{
+			name: "sessions are replayed and saved",
+			setup: func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase) {
+				repository.EXPECT().ListSessionIds(gomock.Any()).Return([]string{"session-1", "orphan"}, nil)
+				repository.EXPECT().FindSession(gomock.Any(), "session-1").Return(nil, entities.ErrNotFound)
+				repository.EXPECT().FindBySession(gomock.Any(), "session-1").Return(events, nil)
+				repository.EXPECT().FindSession(gomock.Any(), "orphan").Return(nil, entities.ErrNotFound)
+				repository.EXPECT().FindBySession(gomock.Any(), "orphan").Return(nil, nil)
+				testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, 3).Return(&entities.Test{TestId: 2, TimeToDo: 30}, nil)
+				repository.EXPECT().SaveSession(gomock.Any(), gomock.Any(), 0).DoAndReturn(func(ctx context.Context, session *entities.Session, expectedVersion int) error {
+					if session.SessionId != "session-1" || session.Answers[1].Answer != "B" || session.Deadline == nil || session.Version != 3 {
+						t.Errorf("unexpected session %+v", session)
+					}
+					return nil
+				})
+			},
+			want: 1,
+		},
+		{
+			name: "extensions granted by proctors are kept",
+			setup: func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase) {
+				extended := append(append([]entities.Event{}, events...),
+					entities.Event{Id: primitive.NewObjectID(), UserId: 1, TestId: 2, Event: entities.EXTEND, Session: "session-1", ActorId: 9, Extension: 10 * time.Minute, CreatedAt: &now})
+				repository.EXPECT().ListSessionIds(gomock.Any()).Return([]string{"session-1"}, nil)
+				repository.EXPECT().FindSession(gomock.Any(), "session-1").Return(nil, entities.ErrNotFound)
+				repository.EXPECT().FindBySession(gomock.Any(), "session-1").Return(extended, nil)
+				testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, 3).Return(&entities.Test{TestId: 2, TimeToDo: 30}, nil)
+				repository.EXPECT().SaveSession(gomock.Any(), gomock.Any(), 0).DoAndReturn(func(ctx context.Context, session *entities.Session, expectedVersion int) error {
+					if session.Deadline == nil || !session.Deadline.Equal(now.Add(40*time.Minute)) {
+						t.Errorf("deadline %v, want %v", session.Deadline, now.Add(40*time.Minute))
+					}
+					return nil
+				})
+			},
+			want: 1,
+		},
+		{
+			name: "paused time is added to the deadline",
+			setup: func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase) {
+				pausedAt := now.Add(5 * time.Minute)
+				resumedAt := pausedAt.Add(15 * time.Minute)
+				paused := append(append([]entities.Event{}, events...),
+					entities.Event{Id: primitive.NewObjectID(), UserId: 1, TestId: 2, Event: entities.PAUSE, Session: "session-1", CreatedAt: &pausedAt},
+					entities.Event{Id: primitive.NewObjectID(), UserId: 1, TestId: 2, Event: entities.RESUME, Session: "session-1", CreatedAt: &resumedAt})
+				repository.EXPECT().ListSessionIds(gomock.Any()).Return([]string{"session-1"}, nil)
+				repository.EXPECT().FindSession(gomock.Any(), "session-1").Return(nil, entities.ErrNotFound)
+				repository.EXPECT().FindBySession(gomock.Any(), "session-1").Return(paused, nil)
+				testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, 3).Return(&entities.Test{TestId: 2, TimeToDo: 30}, nil)
+				repository.EXPECT().SaveSession(gomock.Any(), gomock.Any(), 0).DoAndReturn(func(ctx context.Context, session *entities.Session, expectedVersion int) error {
+					if session.Deadline == nil || !session.Deadline.Equal(now.Add(45*time.Minute)) || session.State != entities.SESSION_IN_PROGRESS {
+						t.Errorf("deadline %v state %s, want %v", session.Deadline, session.State, now.Add(45*time.Minute))
+					}
+					return nil
+				})
+			},
+			want: 1,
+		},
+		{
+			name: "shuffle of the START event is kept",
+			setup: func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase) {
+				started := append([]entities.Event{}, events...)
+				started[0].Shuffle = &entities.Shuffle{Questions: []int{2, 1}, Options: map[int][]string{1: {"B", "A"}}}
+				repository.EXPECT().ListSessionIds(gomock.Any()).Return([]string{"session-1"}, nil)
+				repository.EXPECT().FindSession(gomock.Any(), "session-1").Return(nil, entities.ErrNotFound)
+				repository.EXPECT().FindBySession(gomock.Any(), "session-1").Return(started, nil)
+				testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, 3).Return(shuffledTest, nil)
+				repository.EXPECT().SaveSession(gomock.Any(), gomock.Any(), 0).DoAndReturn(func(ctx context.Context, session *entities.Session, expectedVersion int) error {
+					if !reflect.DeepEqual(session.Shuffle, started[0].Shuffle) {
+						t.Errorf("shuffle %+v, want the one of the START event", session.Shuffle)
+					}
+					return nil
+				})
+			},
+			want: 1,
+		},
+		{
+			name: "stored shuffle is kept for sessions started without one",
+			setup: func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase) {
+				shuffle := &entities.Shuffle{Questions: []int{2, 1}, Options: map[int][]string{1: {"B", "A"}}}
+				repository.EXPECT().ListSessionIds(gomock.Any()).Return([]string{"session-1"}, nil)
+				repository.EXPECT().FindSession(gomock.Any(), "session-1").Return(&entities.Session{SessionId: "session-1", Version: 2, Shuffle: shuffle}, nil)
+				repository.EXPECT().FindBySession(gomock.Any(), "session-1").Return(events, nil)
+				testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, 3).Return(shuffledTest, nil)
+				repository.EXPECT().SaveSession(gomock.Any(), gomock.Any(), 2).DoAndReturn(func(ctx context.Context, session *entities.Session, expectedVersion int) error {
+					if !reflect.DeepEqual(session.Shuffle, shuffle) {
+						t.Errorf("shuffle %+v, want the stored one", session.Shuffle)
+					}
+					return nil
+				})
+			},
+			want: 1,
+		},
+		{
+			name: "attempts follow the start order",
+			setup: func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase) {
+				repository.EXPECT().ListSessionIds(gomock.Any()).Return([]string{"session-2", "session-1"}, nil)
+				repository.EXPECT().FindSession(gomock.Any(), "session-2").Return(nil, entities.ErrNotFound)
+				repository.EXPECT().FindBySession(gomock.Any(), "session-2").Return(retake, nil)
+				repository.EXPECT().FindSession(gomock.Any(), "session-1").Return(nil, entities.ErrNotFound)
+				repository.EXPECT().FindBySession(gomock.Any(), "session-1").Return(events, nil)
+				testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, 3).Return(&entities.Test{TestId: 2}, nil)
+				attempts := map[string]int{"session-1": 1, "session-2": 2}
+				repository.EXPECT().SaveSession(gomock.Any(), gomock.Any(), 0).Times(2).DoAndReturn(func(ctx context.Context, session *entities.Session, expectedVersion int) error {
+					if session.Attempt != attempts[session.SessionId] {
+						t.Errorf("session %s got attempt %d", session.SessionId, session.Attempt)
+					}
+					return nil
+				})
+			},
+			want: 2,
+		},
+		{
+			name: "stored session is replaced at the version read",
+			setup: func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase) {
+				repository.EXPECT().ListSessionIds(gomock.Any()).Return([]string{"session-1"}, nil)
+				repository.EXPECT().FindSession(gomock.Any(), "session-1").Return(&entities.Session{SessionId: "session-1", Version: 2}, nil)
+				repository.EXPECT().FindBySession(gomock.Any(), "session-1").Return(events, nil)
+				testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, 3).Return(&entities.Test{TestId: 2}, nil)
+				repository.EXPECT().SaveSession(gomock.Any(), gomock.Any(), 2).Return(nil)
+			},
+			want: 1,
+		},
+		{
+			name: "session written during the rebuild is replayed again",
+			setup: func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase) {
+				answered := append(append([]entities.Event{}, events...),
+					entities.Event{Id: primitive.NewObjectID(), UserId: 1, TestId: 2, Event: entities.ANSWER, Session: "session-1", QuestionId: 2, Answer: "C", CreatedAt: &now})
+				repository.EXPECT().ListSessionIds(gomock.Any()).Return([]string{"session-1"}, nil)
+				gomock.InOrder(
+					repository.EXPECT().FindSession(gomock.Any(), "session-1").Return(&entities.Session{SessionId: "session-1", Version: 3}, nil),
+					repository.EXPECT().FindBySession(gomock.Any(), "session-1").Return(events, nil),
+					repository.EXPECT().SaveSession(gomock.Any(), gomock.Any(), 3).Return(entities.ErrVersionConflict),
+					repository.EXPECT().FindSession(gomock.Any(), "session-1").Return(&entities.Session{SessionId: "session-1", Version: 4}, nil),
+					repository.EXPECT().FindBySession(gomock.Any(), "session-1").Return(answered, nil),
+					repository.EXPECT().SaveSession(gomock.Any(), gomock.Any(), 4).DoAndReturn(func(ctx context.Context, session *entities.Session, expectedVersion int) error {
+						if session.Version != 4 || session.Answers[2].Answer != "C" || session.Attempt != 1 {
+							t.Errorf("unexpected session %+v", session)
+						}
+						return nil
+					}),
+				)
+				testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, 3).Return(&entities.Test{TestId: 2}, nil)
+			},
+			want: 1,
+		},
+		{
+			name: "session written on every replay",
+			setup: func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase) {
+				repository.EXPECT().ListSessionIds(gomock.Any()).Return([]string{"session-1"}, nil)
+				repository.EXPECT().FindSession(gomock.Any(), "session-1").Return(&entities.Session{SessionId: "session-1", Version: 3}, nil).Times(3)
+				repository.EXPECT().FindBySession(gomock.Any(), "session-1").Return(events, nil).Times(3)
+				testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, 3).Return(&entities.Test{TestId: 2}, nil)
+				repository.EXPECT().SaveSession(gomock.Any(), gomock.Any(), 3).Return(entities.ErrVersionConflict).Times(3)
+			},
+			wantErr: entities.ErrVersionConflict,
+		},
+		{
+			name: "list error",
+			setup: func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase) {
+				repository.EXPECT().ListSessionIds(gomock.Any()).Return(nil, errStorage)
+			},
+			wantErr: errStorage,
+		},
+		{
+			name: "save error",
+			setup: func(repository *mocks.MockIAnswersheetRepository, testUsecase *mocks.MockITestUsecase) {
+				repository.EXPECT().ListSessionIds(gomock.Any()).Return([]string{"session-1"}, nil)
+				repository.EXPECT().FindSession(gomock.Any(), "session-1").Return(nil, entities.ErrNotFound)
+				repository.EXPECT().FindBySession(gomock.Any(), "session-1").Return(events, nil)
+				testUsecase.EXPECT().GetByTestIdAndVersion(gomock.Any(), 2, 3).Return(nil, entities.ErrNotFound)
+				repository.EXPECT().SaveSession(gomock.Any(), gomock.Any(), 0).Return(errStorage)
+			},
+			wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repository := mocks.NewMockIAnswersheetRepository(ctrl)
+			testUsecase := mocks.NewMockITestUsecase(ctrl)
+			tt.setup(repository, testUsecase)
+			got, err := NewSessionUsecase(repository, testUsecase).Rebuild(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Rebuild() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Rebuild() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,16 +1,18 @@
 package usecase
 
+//go:generate mockgen -source=test.go -destination=../mocks/test.go -package=mocks
+
 import (
 	"context"
 	"errors"
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
 	"picket-answersheet-service/src/internal/entities"
 )
 
 type ITestRepository interface {
 	FindByTestId(ctx context.Context, testId int) (*entities.Test, error)
+	FindByTestIdAndVersion(ctx context.Context, testId int, version int) (*entities.Test, error)
 	Create(ctx context.Context, test *entities.Test) error
 }
 
@@ -22,14 +24,16 @@ func NewTestUsecase(repository ITestRepository) *testUsecase {
 	return &testUsecase{repository: repository}
 }
 
+// SyncTest stores a version of a test once; earlier versions are kept for the
+// sessions that started with them.
 func (u *testUsecase) SyncTest(ctx context.Context, test *entities.Test) error {
-	check, err := u.repository.FindByTestId(ctx, test.TestId)
-	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+	check, err := u.repository.FindByTestIdAndVersion(ctx, test.TestId, test.Version)
+	if err != nil && !errors.Is(err, entities.ErrNotFound) {
 		log.Error().Err(err).Send()
 		return err
 	}
 	if check != nil {
-		log.Info().Int("test_id", test.TestId).Send()
+		log.Info().Int("test_id", test.TestId).Int("version", test.Version).Send()
 		return nil
 	}
 
@@ -37,6 +41,7 @@ func (u *testUsecase) SyncTest(ctx context.Context, test *entities.Test) error {
 	err = u.repository.Create(ctx, test)
 	if err != nil {
 		log.Error().Err(err).Send()
+		return err
 	}
 
 	return nil
@@ -50,3 +55,14 @@ func (u *testUsecase) GetByTestId(ctx context.Context, testId int) (*entities.Te
 	}
 	return result, nil
 }
+
+// GetByTestIdAndVersion returns the version of the test a session started
+// with.
+func (u *testUsecase) GetByTestIdAndVersion(ctx context.Context, testId int, version int) (*entities.Test, error) {
+	result, err := u.repository.FindByTestIdAndVersion(ctx, testId, version)
+	if err != nil {
+		log.Error().Err(err).Send()
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"picket-answersheet-service/src/internal/entities"
+	"picket-answersheet-service/src/internal/mocks"
+	"testing"
+)
+
+func TestTestUsecase_SyncTest(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(repository *mocks.MockITestRepository)
+		wantErr error
+	}{
+		{
+			name: "new test is created",
+			setup: func(repository *mocks.MockITestRepository) {
+				repository.EXPECT().FindByTestIdAndVersion(gomock.Any(), 2, 3).Return(nil, entities.ErrNotFound)
+				repository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, test *entities.Test) error {
+					if test.Id.IsZero() {
+						t.Error("test id not set")
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "known version is skipped",
+			setup: func(repository *mocks.MockITestRepository) {
+				repository.EXPECT().FindByTestIdAndVersion(gomock.Any(), 2, 3).Return(&entities.Test{TestId: 2, Version: 3}, nil)
+			},
+		},
+		{
+			name: "lookup error",
+			setup: func(repository *mocks.MockITestRepository) {
+				repository.EXPECT().FindByTestIdAndVersion(gomock.Any(), 2, 3).Return(nil, errStorage)
+			},
+			wantErr: errStorage,
+		},
+		{
+			name: "create error",
+			setup: func(repository *mocks.MockITestRepository) {
+				repository.EXPECT().FindByTestIdAndVersion(gomock.Any(), 2, 3).Return(nil, entities.ErrNotFound)
+				repository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errStorage)
+			},
+			wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := mocks.NewMockITestRepository(gomock.NewController(t))
+			tt.setup(repository)
+			err := NewTestUsecase(repository).SyncTest(context.Background(), &entities.Test{TestId: 2, Version: 3})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SyncTest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTestUsecase_GetByTestId(t *testing.T) {
+	tests := []struct {
+		name    string
+		test    *entities.Test
+		err     error
+		wantErr error
+	}{
+		{name: "found", test: &entities.Test{TestId: 2, Name: "math"}},
+		{name: "not found", err: entities.ErrNotFound, wantErr: entities.ErrNotFound},
+		{name: "lookup error", err: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := mocks.NewMockITestRepository(gomock.NewController(t))
+			repository.EXPECT().FindByTestId(gomock.Any(), 2).Return(tt.test, tt.err)
+			got, err := NewTestUsecase(repository).GetByTestId(context.Background(), 2)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetByTestId() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.test {
+				t.Fatalf("GetByTestId() = %v, want %v", got, tt.test)
+			}
+		})
+	}
+}
+
+func TestTestUsecase_GetByTestIdAndVersion(t *testing.T) {
+	repository := mocks.NewMockITestRepository(gomock.NewController(t))
+	u := NewTestUsecase(repository)
+
+	test := &entities.Test{TestId: 2, Version: 3}
+	repository.EXPECT().FindByTestIdAndVersion(gomock.Any(), 2, 3).Return(test, nil)
+	if got, err := u.GetByTestIdAndVersion(context.Background(), 2, 3); got != test || err != nil {
+		t.Fatalf("GetByTestIdAndVersion() = %v, %v, want %v", got, err, test)
+	}
+
+	repository.EXPECT().FindByTestIdAndVersion(gomock.Any(), 2, 4).Return(nil, entities.ErrNotFound)
+	if _, err := u.GetByTestIdAndVersion(context.Background(), 2, 4); !errors.Is(err, entities.ErrNotFound) {
+		t.Fatalf("GetByTestIdAndVersion() error = %v, want %v", err, entities.ErrNotFound)
+	}
+}
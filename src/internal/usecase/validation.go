@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every field of an input that was rejected. It is never
+// retried and its fields are sent along with the job-fail notification.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for index, item := range e.Fields {
+		messages[index] = item.Field + ": " + item.Message
+	}
+	return "invalid input: " + strings.Join(messages, "; ")
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// validateStruct runs the validate tags of input and reports the failures as
+// field errors named after the json path.
+func validateStruct(input interface{}) error {
+	err := validate.Struct(input)
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+	fields := make([]FieldError, len(validationErrors))
+	for index, item := range validationErrors {
+		// drop the struct name, the path starts at the json root
+		field := item.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		message := "failed " + item.Tag()
+		if item.Param() != "" {
+			message += "=" + item.Param()
+		}
+		fields[index] = FieldError{Field: field, Message: message}
+	}
+	return &ValidationError{Fields: fields}
+}
+
+// validateAnswer checks an answer against the question set of the test. Tests
+// not synced yet, or without content, cannot be checked and are accepted.
+func validateAnswer(input dto.UserAnswerInput, test *entities.Test) error {
+	if test == nil || test.Content == nil || test.Content.MultipleChoice == nil {
+		return nil
+	}
+	question, ok := test.Question(input.Payload.QuestionId)
+	if !ok {
+		return unknownQuestion(input.Payload.QuestionId, test)
+	}
+	if message := checkAnswer(question, input.Payload.Answer); message != "" {
+		return &ValidationError{Fields: []FieldError{{Field: "payload.answer", Message: message}}}
+	}
+	return nil
+}
+
+// validateQuestion checks that the question is in the test, like
+// validateAnswer does.
+func validateQuestion(questionId int, test *entities.Test) error {
+	if test == nil || test.Content == nil || test.Content.MultipleChoice == nil {
+		return nil
+	}
+	if _, ok := test.Question(questionId); !ok {
+		return unknownQuestion(questionId, test)
+	}
+	return nil
+}
+
+func unknownQuestion(questionId int, test *entities.Test) error {
+	return &ValidationError{Fields: []FieldError{{
+		Field:   "payload.question_id",
+		Message: fmt.Sprintf("question %d is not in test %d", questionId, test.TestId),
+	}}}
+}
+
+// checkAnswer returns why answer does not fit the question, or an empty
+// string.
+func checkAnswer(question *entities.TestMultipleChoiceAnswer, answer string) string {
+	labels := question.Labels()
+	switch question.Type {
+	case entities.QUESTION_CHOICE:
+		if !isOption(labels, answer) {
+			return fmt.Sprintf("must be one of %s", strings.Join(labels, ", "))
+		}
+	case entities.QUESTION_MULTIPLE:
+		seen := make(map[string]bool)
+		for _, item := range strings.Split(answer, ",") {
+			item = strings.TrimSpace(item)
+			if !isOption(labels, item) || seen[item] {
+				return fmt.Sprintf("must be distinct options of %s separated by commas", strings.Join(labels, ", "))
+			}
+			seen[item] = true
+		}
+	case entities.QUESTION_NUMBER:
+		if _, err := strconv.ParseFloat(strings.TrimSpace(answer), 64); err != nil {
+			return "must be a number"
+		}
+	case entities.QUESTION_TEXT:
+	default:
+		return fmt.Sprintf("question type %d is not supported", question.Type)
+	}
+	return ""
+}
+
+func isOption(labels []string, value string) bool {
+	for _, item := range labels {
+		if value == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"picket-answersheet-service/src/internal/dto"
+	"picket-answersheet-service/src/internal/entities"
+	"reflect"
+	"testing"
+)
+
+func TestValidateStruct(t *testing.T) {
+	input := answerInput(0, "")
+	input.Payload.UserId = 0
+
+	err := validateStruct(input)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("validateStruct() error = %v, want *ValidationError", err)
+	}
+	var fields []string
+	for _, item := range validationErr.Fields {
+		fields = append(fields, item.Field)
+	}
+	want := []string{"payload.user_id", "payload.answer", "payload.question_id"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Fatalf("validateStruct() fields = %v, want %v", fields, want)
+	}
+
+	if err := validateStruct(answerInput(1, "A")); err != nil {
+		t.Fatalf("validateStruct() error = %v", err)
+	}
+}
+
+func TestValidateAnswer(t *testing.T) {
+	test := &entities.Test{TestId: 2, Content: &entities.TestContent{MultipleChoice: &entities.TestMultipleChoice{
+		Answers: []entities.TestMultipleChoiceAnswer{
+			{Id: 1, Answer: "A"},
+			{Id: 2, Answer: "A,C", Type: entities.QUESTION_MULTIPLE},
+			{Id: 3, Answer: "free", Type: entities.QUESTION_TEXT},
+			{Id: 4, Answer: "42", Type: entities.QUESTION_NUMBER},
+			{Id: 5, Answer: "?", Type: 99},
+		},
+	}}}
+
+	tests := []struct {
+		name      string
+		test      *entities.Test
+		input     dto.UserAnswerInput
+		wantField string
+	}{
+		{name: "single option", test: test, input: answerInput(1, "B")},
+		{name: "single option not on the sheet", test: test, input: answerInput(1, "E"), wantField: "payload.answer"},
+		{name: "several options", test: test, input: answerInput(1, "A,B"), wantField: "payload.answer"},
+		{name: "multiple options", test: test, input: answerInput(2, "A, D")},
+		{name: "multiple options repeated", test: test, input: answerInput(2, "A,A"), wantField: "payload.answer"},
+		{name: "multiple options with a blank", test: test, input: answerInput(2, "A,"), wantField: "payload.answer"},
+		{name: "text", test: test, input: answerInput(3, "anything goes")},
+		{name: "number", test: test, input: answerInput(4, " 3.5 ")},
+		{name: "not a number", test: test, input: answerInput(4, "three"), wantField: "payload.answer"},
+		{name: "unknown question type", test: test, input: answerInput(5, "A"), wantField: "payload.answer"},
+		{name: "unknown question", test: test, input: answerInput(6, "A"), wantField: "payload.question_id"},
+		{name: "test not synced", input: answerInput(6, "A")},
+		{name: "test without content", test: &entities.Test{TestId: 2}, input: answerInput(6, "A")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAnswer(tt.input, tt.test)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("validateAnswer() error = %v", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("validateAnswer() error = %v, want *ValidationError", err)
+			}
+			if len(validationErr.Fields) != 1 || validationErr.Fields[0].Field != tt.wantField {
+				t.Fatalf("validateAnswer() fields = %v, want %s", validationErr.Fields, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestNewJobFailOutbox_FieldErrors(t *testing.T) {
+	outbox, err := newJobFailOutbox(7, &ValidationError{Fields: []FieldError{{Field: "payload.answer", Message: "must be a number"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload struct {
+		JobId        int          `json:"job_id"`
+		ErrorMessage string       `json:"error_message"`
+		Errors       []FieldError `json:"errors"`
+	}
+	if err := json.Unmarshal(outbox.Payload, &payload); err != nil {
+		t.Fatal(err)
+	}
+	want := []FieldError{{Field: "payload.answer", Message: "must be a number"}}
+	if payload.JobId != 7 || payload.ErrorMessage == "" || !reflect.DeepEqual(payload.Errors, want) {
+		t.Fatalf("job-fail payload = %+v", payload)
+	}
+}
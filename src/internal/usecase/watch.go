@@ -0,0 +1,149 @@
+package usecase
+
+//go:generate mockgen -source=watch.go -destination=../mocks/watch.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/entities"
+	"time"
+)
+
+// IHub fans session updates out to watchers, Subscribe with user id 0 watches
+// every user of the test.
+type IHub interface {
+	Publish(update entities.SessionUpdate)
+	Subscribe(userId int, testId int) (<-chan entities.SessionUpdate, func())
+}
+
+type watchUsecase struct {
+	repository IAnswersheetRepository
+	hub        IHub
+	config     config.IConfig
+}
+
+func NewWatchUsecase(repository IAnswersheetRepository, hub IHub, config config.IConfig) *watchUsecase {
+	return &watchUsecase{repository: repository, hub: hub, config: config}
+}
+
+// WatchSession sends a snapshot of the latest session of the user at the test,
+// then every update published for it until ctx is done or send fails. Each
+// interval it sends a TICK and reads the session again, which picks up writes
+// made by other instances and the expiry of the deadline.
+func (u *watchUsecase) WatchSession(ctx context.Context, userId int, testId int, send func(update entities.SessionUpdate) error) error {
+	updates, cancel := u.hub.Subscribe(userId, testId)
+	defer cancel()
+
+	current, err := u.latest(ctx, userId, testId)
+	if err != nil {
+		return err
+	}
+	state := current.StateAt(time.Now())
+	if err := send(entities.SessionUpdate{Type: entities.UPDATE_SNAPSHOT, Session: *current, At: time.Now()}); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(u.config.GetWatchInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update := <-updates:
+			// an update older than what was already sent is stale
+			if update.Session.SessionId == current.SessionId && update.Session.Version <= current.Version {
+				continue
+			}
+			current = &update.Session
+			state = current.StateAt(update.At)
+			if err := send(update); err != nil {
+				return err
+			}
+		case now := <-ticker.C:
+			latest, err := u.latest(ctx, userId, testId)
+			if err != nil {
+				return err
+			}
+			if latest.SessionId != current.SessionId || latest.Version > current.Version {
+				current = latest
+			}
+			if next := current.StateAt(now); next != state {
+				state = next
+				if err := send(entities.SessionUpdate{Type: entities.UPDATE_STATE, Session: *current, At: now}); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := send(entities.SessionUpdate{Type: entities.UPDATE_TICK, Session: *current, At: now}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ListActiveSessions returns the sessions of the test in progress or paused,
+// including the ones whose deadline passed but that are not submitted yet.
+func (u *watchUsecase) ListActiveSessions(ctx context.Context, testId int) ([]entities.Session, error) {
+	return u.repository.FindActiveSessions(ctx, testId)
+}
+
+// WatchTest sends a SNAPSHOT of every active session of the test, then every
+// update published for a session of the test until ctx is done or send fails.
+// Each interval it reads the active sessions again and sends a TICK for each of
+// them; a session missing from a round of TICKs has ended.
+func (u *watchUsecase) WatchTest(ctx context.Context, testId int, send func(update entities.SessionUpdate) error) error {
+	updates, cancel := u.hub.Subscribe(0, testId)
+	defer cancel()
+
+	versions := make(map[string]int)
+	sendAll := func(updateType string, now time.Time) error {
+		sessions, err := u.repository.FindActiveSessions(ctx, testId)
+		if err != nil {
+			return err
+		}
+		for _, session := range sessions {
+			if session.Version > versions[session.SessionId] {
+				versions[session.SessionId] = session.Version
+			}
+			if err := send(entities.SessionUpdate{Type: updateType, Session: session, At: now}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := sendAll(entities.UPDATE_SNAPSHOT, time.Now()); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(u.config.GetWatchInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update := <-updates:
+			if update.Session.Version <= versions[update.Session.SessionId] {
+				continue
+			}
+			versions[update.Session.SessionId] = update.Session.Version
+			if err := send(update); err != nil {
+				return err
+			}
+		case now := <-ticker.C:
+			if err := sendAll(entities.UPDATE_TICK, now); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (u *watchUsecase) latest(ctx context.Context, userId int, testId int) (*entities.Session, error) {
+	session, err := u.repository.FindLatestSession(ctx, userId, testId)
+	if errors.Is(err, entities.ErrNotFound) {
+		return entities.NewSession(userId, testId), nil
+	}
+	return session, err
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"picket-answersheet-service/src/internal/entities"
+	"picket-answersheet-service/src/internal/mocks"
+	"testing"
+	"time"
+)
+
+type watchFixture struct {
+	repository *mocks.MockIAnswersheetRepository
+	hub        *mocks.MockIHub
+	updates    chan entities.SessionUpdate
+	usecase    *watchUsecase
+}
+
+func newWatchFixture(t *testing.T, interval time.Duration) *watchFixture {
+	ctrl := gomock.NewController(t)
+	f := &watchFixture{
+		repository: mocks.NewMockIAnswersheetRepository(ctrl),
+		hub:        mocks.NewMockIHub(ctrl),
+		updates:    make(chan entities.SessionUpdate, 4),
+	}
+	iConfig := mocks.NewMockIConfig(ctrl)
+	iConfig.EXPECT().GetWatchInterval().Return(interval).AnyTimes()
+	f.hub.EXPECT().Subscribe(1, 2).Return((<-chan entities.SessionUpdate)(f.updates), func() {})
+	f.usecase = NewWatchUsecase(f.repository, f.hub, iConfig)
+	return f
+}
+
+// watch runs WatchSession until it has sent count updates and returns them.
+func (f *watchFixture) watch(t *testing.T, count int) ([]entities.SessionUpdate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var sent []entities.SessionUpdate
+	errDone := errors.New("done")
+	err := f.usecase.WatchSession(ctx, 1, 2, func(update entities.SessionUpdate) error {
+		sent = append(sent, update)
+		if len(sent) == count {
+			return errDone
+		}
+		return nil
+	})
+	if errors.Is(err, errDone) {
+		err = nil
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("WatchSession() sent %d updates, want %d", len(sent), count)
+	}
+	return sent, err
+}
+
+func TestWatchUsecase_WatchSession(t *testing.T) {
+	t.Run("snapshot then published updates, stale ones skipped", func(t *testing.T) {
+		f := newWatchFixture(t, time.Hour)
+		f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(inProgress(), nil)
+
+		stale := *inProgress()
+		answered := *inProgress()
+		answered.Version = 2
+		f.updates <- entities.SessionUpdate{Type: entities.UPDATE_ANSWER, Session: stale, At: time.Now()}
+		f.updates <- entities.SessionUpdate{Type: entities.UPDATE_ANSWER, Session: answered, At: time.Now()}
+
+		sent, err := f.watch(t, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sent[0].Type != entities.UPDATE_SNAPSHOT || sent[1].Type != entities.UPDATE_ANSWER || sent[1].Session.Version != 2 {
+			t.Fatalf("WatchSession() sent %+v", sent)
+		}
+	})
+
+	t.Run("never started gets a not started snapshot", func(t *testing.T) {
+		f := newWatchFixture(t, time.Hour)
+		f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(nil, entities.ErrNotFound)
+
+		sent, err := f.watch(t, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sent[0].Session.State != entities.SESSION_NOT_STARTED {
+			t.Fatalf("WatchSession() sent %+v", sent)
+		}
+	})
+
+	t.Run("ticks resync and report the deadline passing", func(t *testing.T) {
+		f := newWatchFixture(t, 10*time.Millisecond)
+		running := inProgress()
+		deadline := time.Now().Add(50 * time.Millisecond)
+		running.Deadline = &deadline
+		f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(running, nil).AnyTimes()
+
+		// stop at the STATE update sent when the deadline passes
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var types []string
+		errDone := errors.New("done")
+		err := f.usecase.WatchSession(ctx, 1, 2, func(update entities.SessionUpdate) error {
+			types = append(types, update.Type)
+			if update.Type == entities.UPDATE_STATE {
+				if state := update.Session.StateAt(update.At); state != entities.SESSION_EXPIRED {
+					t.Errorf("state = %s, want %s", state, entities.SESSION_EXPIRED)
+				}
+				return errDone
+			}
+			return nil
+		})
+		if !errors.Is(err, errDone) {
+			t.Fatalf("WatchSession() error = %v, sent %v", err, types)
+		}
+		if types[0] != entities.UPDATE_SNAPSHOT || types[1] != entities.UPDATE_TICK {
+			t.Fatalf("WatchSession() sent %v", types)
+		}
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		f := newWatchFixture(t, time.Hour)
+		f.repository.EXPECT().FindLatestSession(gomock.Any(), 1, 2).Return(nil, errStorage)
+
+		err := f.usecase.WatchSession(context.Background(), 1, 2, func(update entities.SessionUpdate) error {
+			t.Fatalf("unexpected update %+v", update)
+			return nil
+		})
+		if !errors.Is(err, errStorage) {
+			t.Fatalf("WatchSession() error = %v, want %v", err, errStorage)
+		}
+	})
+}
+
+func TestWatchUsecase_WatchTest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := mocks.NewMockIAnswersheetRepository(ctrl)
+	hub := mocks.NewMockIHub(ctrl)
+	iConfig := mocks.NewMockIConfig(ctrl)
+	iConfig.EXPECT().GetWatchInterval().Return(time.Hour).AnyTimes()
+	updates := make(chan entities.SessionUpdate, 4)
+	hub.EXPECT().Subscribe(0, 2).Return((<-chan entities.SessionUpdate)(updates), func() {})
+
+	first := *inProgress()
+	second := *inProgress()
+	second.SessionId = "session-2"
+	second.UserId = 3
+	repository.EXPECT().FindActiveSessions(gomock.Any(), 2).Return([]entities.Session{first, second}, nil)
+
+	// the first is already sent in the snapshot, the second is new
+	answered := second
+	answered.Version = 2
+	updates <- entities.SessionUpdate{Type: entities.UPDATE_ANSWER, Session: first, At: time.Now()}
+	updates <- entities.SessionUpdate{Type: entities.UPDATE_ANSWER, Session: answered, At: time.Now()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var sent []entities.SessionUpdate
+	errDone := errors.New("done")
+	err := NewWatchUsecase(repository, hub, iConfig).WatchTest(ctx, 2, func(update entities.SessionUpdate) error {
+		sent = append(sent, update)
+		if len(sent) == 3 {
+			return errDone
+		}
+		return nil
+	})
+	if !errors.Is(err, errDone) {
+		t.Fatalf("WatchTest() error = %v, sent %+v", err, sent)
+	}
+	if sent[0].Type != entities.UPDATE_SNAPSHOT || sent[1].Type != entities.UPDATE_SNAPSHOT ||
+		sent[2].Type != entities.UPDATE_ANSWER || sent[2].Session.SessionId != "session-2" {
+		t.Fatalf("WatchTest() sent %+v", sent)
+	}
+}
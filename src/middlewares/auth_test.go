@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAuthenticate(t *testing.T) {
+	now := time.Now()
+	proctor := Identity{UserId: 9, Role: ROLE_PROCTOR, Tests: []int{2}}
+	token := func(secret string, expiresAt time.Time) string {
+		result, err := SignToken(secret, proctor, expiresAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name          string
+		secret        string
+		authorization string
+		want          *Identity
+		wantCode      codes.Code
+	}{
+		{name: "no token", secret: "secret"},
+		{name: "valid token", secret: "secret", authorization: "bearer " + token("secret", now.Add(time.Minute)), want: &proctor},
+		{name: "other secret", secret: "secret", authorization: "bearer " + token("other", now.Add(time.Minute)), wantCode: codes.Unauthenticated},
+		{name: "expired", secret: "secret", authorization: "bearer " + token("secret", now), wantCode: codes.Unauthenticated},
+		{name: "no secret configured", authorization: "bearer " + token("", now.Add(time.Minute)), wantCode: codes.Unauthenticated},
+		{name: "other scheme", secret: "secret", authorization: "basic dXNlcg==", wantCode: codes.Unauthenticated},
+		{name: "malformed", secret: "secret", authorization: "bearer a.b", wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+			got, err := Authenticate(tt.secret, func() time.Time { return now })(ctx)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if identity := IdentityFrom(got); !reflect.DeepEqual(identity, tt.want) {
+				t.Fatalf("IdentityFrom() = %v, want %v", identity, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentity_CanProctor(t *testing.T) {
+	if !(Identity{Role: ROLE_PROCTOR, Tests: []int{2}}).CanProctor(2) {
+		t.Fatal("proctor of the test was refused")
+	}
+	if (Identity{Role: ROLE_PROCTOR, Tests: []int{2}}).CanProctor(3) {
+		t.Fatal("proctor of another test was allowed")
+	}
+	if (Identity{Role: ROLE_STUDENT, Tests: []int{2}}).CanProctor(2) {
+		t.Fatal("student was allowed")
+	}
+}
@@ -28,6 +28,18 @@ type AnswerSheetServiceClient interface {
 	GetCurrentTest(ctx context.Context, in *GetCurrentTestRequest, opts ...grpc.CallOption) (*GetCurrentTestResponse, error)
 	CheckUserSubmitted(ctx context.Context, in *CheckUserSubmittedRequest, opts ...grpc.CallOption) (*CheckUserSubmittedResponse, error)
 	GetScore(ctx context.Context, in *GetScoreRequest, opts ...grpc.CallOption) (*GetScoreResponse, error)
+	WatchSession(ctx context.Context, in *WatchSessionRequest, opts ...grpc.CallOption) (AnswerSheetService_WatchSessionClient, error)
+	AnswerStream(ctx context.Context, opts ...grpc.CallOption) (AnswerSheetService_AnswerStreamClient, error)
+	ListActiveSessions(ctx context.Context, in *ListActiveSessionsRequest, opts ...grpc.CallOption) (*ListActiveSessionsResponse, error)
+	WatchTest(ctx context.Context, in *WatchTestRequest, opts ...grpc.CallOption) (AnswerSheetService_WatchTestClient, error)
+	ExtendTime(ctx context.Context, in *ProctorActionRequest, opts ...grpc.CallOption) (*ProctorActionResponse, error)
+	ForceSubmit(ctx context.Context, in *ProctorActionRequest, opts ...grpc.CallOption) (*ProctorActionResponse, error)
+	VoidSession(ctx context.Context, in *ProctorActionRequest, opts ...grpc.CallOption) (*ProctorActionResponse, error)
+	PauseTest(ctx context.Context, in *ProctorActionRequest, opts ...grpc.CallOption) (*ProctorActionResponse, error)
+	ResumeTest(ctx context.Context, in *ProctorActionRequest, opts ...grpc.CallOption) (*ProctorActionResponse, error)
+	ResumeSession(ctx context.Context, in *ResumeSessionRequest, opts ...grpc.CallOption) (*ResumeSessionResponse, error)
+	GetSubmitSummary(ctx context.Context, in *GetSubmitSummaryRequest, opts ...grpc.CallOption) (*GetSubmitSummaryResponse, error)
+	SetAccommodation(ctx context.Context, in *SetAccommodationRequest, opts ...grpc.CallOption) (*Accommodation, error)
 }
 
 type answerSheetServiceClient struct {
@@ -92,6 +104,182 @@ func (c *answerSheetServiceClient) GetScore(ctx context.Context, in *GetScoreReq
 	return out, nil
 }
 
+func (c *answerSheetServiceClient) WatchSession(ctx context.Context, in *WatchSessionRequest, opts ...grpc.CallOption) (AnswerSheetService_WatchSessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AnswerSheetService_ServiceDesc.Streams[0], "/answer_sheet.AnswerSheetService/WatchSession", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &answerSheetServiceWatchSessionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AnswerSheetService_WatchSessionClient interface {
+	Recv() (*SessionUpdate, error)
+	grpc.ClientStream
+}
+
+type answerSheetServiceWatchSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *answerSheetServiceWatchSessionClient) Recv() (*SessionUpdate, error) {
+	m := new(SessionUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *answerSheetServiceClient) AnswerStream(ctx context.Context, opts ...grpc.CallOption) (AnswerSheetService_AnswerStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AnswerSheetService_ServiceDesc.Streams[1], "/answer_sheet.AnswerSheetService/AnswerStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &answerSheetServiceAnswerStreamClient{stream}
+	return x, nil
+}
+
+type AnswerSheetService_AnswerStreamClient interface {
+	Send(*AnswerCommand) error
+	Recv() (*CommandAck, error)
+	grpc.ClientStream
+}
+
+type answerSheetServiceAnswerStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *answerSheetServiceAnswerStreamClient) Send(m *AnswerCommand) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *answerSheetServiceAnswerStreamClient) Recv() (*CommandAck, error) {
+	m := new(CommandAck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *answerSheetServiceClient) ListActiveSessions(ctx context.Context, in *ListActiveSessionsRequest, opts ...grpc.CallOption) (*ListActiveSessionsResponse, error) {
+	out := new(ListActiveSessionsResponse)
+	err := c.cc.Invoke(ctx, "/answer_sheet.AnswerSheetService/ListActiveSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *answerSheetServiceClient) WatchTest(ctx context.Context, in *WatchTestRequest, opts ...grpc.CallOption) (AnswerSheetService_WatchTestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AnswerSheetService_ServiceDesc.Streams[2], "/answer_sheet.AnswerSheetService/WatchTest", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &answerSheetServiceWatchTestClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AnswerSheetService_WatchTestClient interface {
+	Recv() (*TestUpdate, error)
+	grpc.ClientStream
+}
+
+type answerSheetServiceWatchTestClient struct {
+	grpc.ClientStream
+}
+
+func (x *answerSheetServiceWatchTestClient) Recv() (*TestUpdate, error) {
+	m := new(TestUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *answerSheetServiceClient) ExtendTime(ctx context.Context, in *ProctorActionRequest, opts ...grpc.CallOption) (*ProctorActionResponse, error) {
+	out := new(ProctorActionResponse)
+	err := c.cc.Invoke(ctx, "/answer_sheet.AnswerSheetService/ExtendTime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *answerSheetServiceClient) ForceSubmit(ctx context.Context, in *ProctorActionRequest, opts ...grpc.CallOption) (*ProctorActionResponse, error) {
+	out := new(ProctorActionResponse)
+	err := c.cc.Invoke(ctx, "/answer_sheet.AnswerSheetService/ForceSubmit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *answerSheetServiceClient) VoidSession(ctx context.Context, in *ProctorActionRequest, opts ...grpc.CallOption) (*ProctorActionResponse, error) {
+	out := new(ProctorActionResponse)
+	err := c.cc.Invoke(ctx, "/answer_sheet.AnswerSheetService/VoidSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *answerSheetServiceClient) PauseTest(ctx context.Context, in *ProctorActionRequest, opts ...grpc.CallOption) (*ProctorActionResponse, error) {
+	out := new(ProctorActionResponse)
+	err := c.cc.Invoke(ctx, "/answer_sheet.AnswerSheetService/PauseTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *answerSheetServiceClient) ResumeTest(ctx context.Context, in *ProctorActionRequest, opts ...grpc.CallOption) (*ProctorActionResponse, error) {
+	out := new(ProctorActionResponse)
+	err := c.cc.Invoke(ctx, "/answer_sheet.AnswerSheetService/ResumeTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *answerSheetServiceClient) ResumeSession(ctx context.Context, in *ResumeSessionRequest, opts ...grpc.CallOption) (*ResumeSessionResponse, error) {
+	out := new(ResumeSessionResponse)
+	err := c.cc.Invoke(ctx, "/answer_sheet.AnswerSheetService/ResumeSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *answerSheetServiceClient) GetSubmitSummary(ctx context.Context, in *GetSubmitSummaryRequest, opts ...grpc.CallOption) (*GetSubmitSummaryResponse, error) {
+	out := new(GetSubmitSummaryResponse)
+	err := c.cc.Invoke(ctx, "/answer_sheet.AnswerSheetService/GetSubmitSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *answerSheetServiceClient) SetAccommodation(ctx context.Context, in *SetAccommodationRequest, opts ...grpc.CallOption) (*Accommodation, error) {
+	out := new(Accommodation)
+	err := c.cc.Invoke(ctx, "/answer_sheet.AnswerSheetService/SetAccommodation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AnswerSheetServiceServer is the server API for AnswerSheetService service.
 // All implementations must embed UnimplementedAnswerSheetServiceServer
 // for forward compatibility
@@ -102,6 +290,18 @@ type AnswerSheetServiceServer interface {
 	GetCurrentTest(context.Context, *GetCurrentTestRequest) (*GetCurrentTestResponse, error)
 	CheckUserSubmitted(context.Context, *CheckUserSubmittedRequest) (*CheckUserSubmittedResponse, error)
 	GetScore(context.Context, *GetScoreRequest) (*GetScoreResponse, error)
+	WatchSession(*WatchSessionRequest, AnswerSheetService_WatchSessionServer) error
+	AnswerStream(AnswerSheetService_AnswerStreamServer) error
+	ListActiveSessions(context.Context, *ListActiveSessionsRequest) (*ListActiveSessionsResponse, error)
+	WatchTest(*WatchTestRequest, AnswerSheetService_WatchTestServer) error
+	ExtendTime(context.Context, *ProctorActionRequest) (*ProctorActionResponse, error)
+	ForceSubmit(context.Context, *ProctorActionRequest) (*ProctorActionResponse, error)
+	VoidSession(context.Context, *ProctorActionRequest) (*ProctorActionResponse, error)
+	PauseTest(context.Context, *ProctorActionRequest) (*ProctorActionResponse, error)
+	ResumeTest(context.Context, *ProctorActionRequest) (*ProctorActionResponse, error)
+	ResumeSession(context.Context, *ResumeSessionRequest) (*ResumeSessionResponse, error)
+	GetSubmitSummary(context.Context, *GetSubmitSummaryRequest) (*GetSubmitSummaryResponse, error)
+	SetAccommodation(context.Context, *SetAccommodationRequest) (*Accommodation, error)
 	mustEmbedUnimplementedAnswerSheetServiceServer()
 }
 
@@ -127,6 +327,42 @@ func (UnimplementedAnswerSheetServiceServer) CheckUserSubmitted(context.Context,
 func (UnimplementedAnswerSheetServiceServer) GetScore(context.Context, *GetScoreRequest) (*GetScoreResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetScore not implemented")
 }
+func (UnimplementedAnswerSheetServiceServer) WatchSession(*WatchSessionRequest, AnswerSheetService_WatchSessionServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSession not implemented")
+}
+func (UnimplementedAnswerSheetServiceServer) AnswerStream(AnswerSheetService_AnswerStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method AnswerStream not implemented")
+}
+func (UnimplementedAnswerSheetServiceServer) ListActiveSessions(context.Context, *ListActiveSessionsRequest) (*ListActiveSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActiveSessions not implemented")
+}
+func (UnimplementedAnswerSheetServiceServer) WatchTest(*WatchTestRequest, AnswerSheetService_WatchTestServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTest not implemented")
+}
+func (UnimplementedAnswerSheetServiceServer) ExtendTime(context.Context, *ProctorActionRequest) (*ProctorActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendTime not implemented")
+}
+func (UnimplementedAnswerSheetServiceServer) ForceSubmit(context.Context, *ProctorActionRequest) (*ProctorActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceSubmit not implemented")
+}
+func (UnimplementedAnswerSheetServiceServer) VoidSession(context.Context, *ProctorActionRequest) (*ProctorActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoidSession not implemented")
+}
+func (UnimplementedAnswerSheetServiceServer) PauseTest(context.Context, *ProctorActionRequest) (*ProctorActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseTest not implemented")
+}
+func (UnimplementedAnswerSheetServiceServer) ResumeTest(context.Context, *ProctorActionRequest) (*ProctorActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeTest not implemented")
+}
+func (UnimplementedAnswerSheetServiceServer) ResumeSession(context.Context, *ResumeSessionRequest) (*ResumeSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSession not implemented")
+}
+func (UnimplementedAnswerSheetServiceServer) GetSubmitSummary(context.Context, *GetSubmitSummaryRequest) (*GetSubmitSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubmitSummary not implemented")
+}
+func (UnimplementedAnswerSheetServiceServer) SetAccommodation(context.Context, *SetAccommodationRequest) (*Accommodation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccommodation not implemented")
+}
 func (UnimplementedAnswerSheetServiceServer) mustEmbedUnimplementedAnswerSheetServiceServer() {}
 
 // UnsafeAnswerSheetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -248,6 +484,236 @@ func _AnswerSheetService_GetScore_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AnswerSheetService_WatchSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSessionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnswerSheetServiceServer).WatchSession(m, &answerSheetServiceWatchSessionServer{stream})
+}
+
+type AnswerSheetService_WatchSessionServer interface {
+	Send(*SessionUpdate) error
+	grpc.ServerStream
+}
+
+type answerSheetServiceWatchSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *answerSheetServiceWatchSessionServer) Send(m *SessionUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AnswerSheetService_AnswerStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AnswerSheetServiceServer).AnswerStream(&answerSheetServiceAnswerStreamServer{stream})
+}
+
+type AnswerSheetService_AnswerStreamServer interface {
+	Send(*CommandAck) error
+	Recv() (*AnswerCommand, error)
+	grpc.ServerStream
+}
+
+type answerSheetServiceAnswerStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *answerSheetServiceAnswerStreamServer) Send(m *CommandAck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *answerSheetServiceAnswerStreamServer) Recv() (*AnswerCommand, error) {
+	m := new(AnswerCommand)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _AnswerSheetService_ListActiveSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActiveSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerSheetServiceServer).ListActiveSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/answer_sheet.AnswerSheetService/ListActiveSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerSheetServiceServer).ListActiveSessions(ctx, req.(*ListActiveSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnswerSheetService_WatchTest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnswerSheetServiceServer).WatchTest(m, &answerSheetServiceWatchTestServer{stream})
+}
+
+type AnswerSheetService_WatchTestServer interface {
+	Send(*TestUpdate) error
+	grpc.ServerStream
+}
+
+type answerSheetServiceWatchTestServer struct {
+	grpc.ServerStream
+}
+
+func (x *answerSheetServiceWatchTestServer) Send(m *TestUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AnswerSheetService_ExtendTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProctorActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerSheetServiceServer).ExtendTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/answer_sheet.AnswerSheetService/ExtendTime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerSheetServiceServer).ExtendTime(ctx, req.(*ProctorActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnswerSheetService_ForceSubmit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProctorActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerSheetServiceServer).ForceSubmit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/answer_sheet.AnswerSheetService/ForceSubmit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerSheetServiceServer).ForceSubmit(ctx, req.(*ProctorActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnswerSheetService_VoidSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProctorActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerSheetServiceServer).VoidSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/answer_sheet.AnswerSheetService/VoidSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerSheetServiceServer).VoidSession(ctx, req.(*ProctorActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnswerSheetService_PauseTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProctorActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerSheetServiceServer).PauseTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/answer_sheet.AnswerSheetService/PauseTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerSheetServiceServer).PauseTest(ctx, req.(*ProctorActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnswerSheetService_ResumeTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProctorActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerSheetServiceServer).ResumeTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/answer_sheet.AnswerSheetService/ResumeTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerSheetServiceServer).ResumeTest(ctx, req.(*ProctorActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnswerSheetService_ResumeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerSheetServiceServer).ResumeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/answer_sheet.AnswerSheetService/ResumeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerSheetServiceServer).ResumeSession(ctx, req.(*ResumeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnswerSheetService_GetSubmitSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubmitSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerSheetServiceServer).GetSubmitSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/answer_sheet.AnswerSheetService/GetSubmitSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerSheetServiceServer).GetSubmitSummary(ctx, req.(*GetSubmitSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnswerSheetService_SetAccommodation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccommodationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerSheetServiceServer).SetAccommodation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/answer_sheet.AnswerSheetService/SetAccommodation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerSheetServiceServer).SetAccommodation(ctx, req.(*SetAccommodationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AnswerSheetService_ServiceDesc is the grpc.ServiceDesc for AnswerSheetService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -279,7 +745,60 @@ var AnswerSheetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetScore",
 			Handler:    _AnswerSheetService_GetScore_Handler,
 		},
+		{
+			MethodName: "ListActiveSessions",
+			Handler:    _AnswerSheetService_ListActiveSessions_Handler,
+		},
+		{
+			MethodName: "ExtendTime",
+			Handler:    _AnswerSheetService_ExtendTime_Handler,
+		},
+		{
+			MethodName: "ForceSubmit",
+			Handler:    _AnswerSheetService_ForceSubmit_Handler,
+		},
+		{
+			MethodName: "VoidSession",
+			Handler:    _AnswerSheetService_VoidSession_Handler,
+		},
+		{
+			MethodName: "PauseTest",
+			Handler:    _AnswerSheetService_PauseTest_Handler,
+		},
+		{
+			MethodName: "ResumeTest",
+			Handler:    _AnswerSheetService_ResumeTest_Handler,
+		},
+		{
+			MethodName: "ResumeSession",
+			Handler:    _AnswerSheetService_ResumeSession_Handler,
+		},
+		{
+			MethodName: "GetSubmitSummary",
+			Handler:    _AnswerSheetService_GetSubmitSummary_Handler,
+		},
+		{
+			MethodName: "SetAccommodation",
+			Handler:    _AnswerSheetService_SetAccommodation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSession",
+			Handler:       _AnswerSheetService_WatchSession_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AnswerStream",
+			Handler:       _AnswerSheetService_AnswerStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchTest",
+			Handler:       _AnswerSheetService_WatchTest_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "answer_sheet.proto",
 }
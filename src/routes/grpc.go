@@ -4,21 +4,31 @@ import (
 	"context"
 	"google.golang.org/grpc"
 	"picket-answersheet-service/src/config"
-	"picket-answersheet-service/src/internal/repository"
+	"picket-answersheet-service/src/internal/codec"
+	"picket-answersheet-service/src/internal/hub"
 	"picket-answersheet-service/src/internal/transport"
 	"picket-answersheet-service/src/internal/usecase"
 	answersheetpb "picket-answersheet-service/src/pb/answer_sheet"
 )
 
-func Grpc(ctx context.Context, s *grpc.Server, config config.IConfig) {
+func Grpc(ctx context.Context, s *grpc.Server, config config.IConfig, storage Storage, broker transport.IBroker, notificationPublisher usecase.IPublisher) {
 
-	testRepository := repository.NewTestRepository(config.GetMongo())
-	testUsecase := usecase.NewTestUsecase(testRepository)
-	transport.NewTestTransport(ctx, testUsecase, config)
+	testUsecase := usecase.NewTestUsecase(storage.Test)
+	transport.NewTestTransport(ctx, testUsecase, config, broker)
 
-	answersheetRepository := repository.NewAnswersheetRepository(config.GetMongo())
-	answersheetUsecase := usecase.NewAnswersheetUsecase(answersheetRepository, config, testUsecase)
-	answersheetTransport := transport.NewAnswerSheetTransport(ctx, answersheetUsecase, config)
+	outboxUsecase := usecase.NewOutboxUsecase(storage.Outbox, notificationPublisher)
+	transport.NewOutboxTransport(ctx, outboxUsecase, config)
+
+	sessionHub := hub.NewHub()
+
+	accommodationUsecase := usecase.NewAccommodationUsecase(storage.Accommodation, storage.Answersheet, testUsecase, sessionHub)
+	transport.NewAccommodationTransport(ctx, accommodationUsecase, config, broker)
+
+	watchUsecase := usecase.NewWatchUsecase(storage.Answersheet, sessionHub, config)
+
+	answersheetUsecase := usecase.NewAnswersheetUsecase(storage.Answersheet, config, testUsecase, notificationPublisher, storage.Outbox, sessionHub, accommodationUsecase)
+	answersheetTransport := transport.NewAnswerSheetTransport(ctx, answersheetUsecase, watchUsecase, accommodationUsecase, config, codec.NewDefaultRegistry(), broker)
+	transport.NewSweeperTransport(ctx, answersheetUsecase, config)
 
 	answersheetpb.RegisterAnswerSheetServiceServer(s, answersheetTransport)
 }
@@ -0,0 +1,62 @@
+package routes
+
+import (
+	"fmt"
+	"picket-answersheet-service/src/config"
+	"picket-answersheet-service/src/internal/repository"
+	"picket-answersheet-service/src/internal/repository/memory"
+	"picket-answersheet-service/src/internal/repository/postgres"
+	"picket-answersheet-service/src/internal/usecase"
+)
+
+type Storage struct {
+	Answersheet usecase.IAnswersheetRepository
+	Test        usecase.ITestRepository
+	Outbox      usecase.IOutboxRepository
+
+	Accommodation usecase.IAccommodationRepository
+}
+
+// NewStorage builds the repositories of backend, or of the one chosen by
+// STORAGE_BACKEND when backend is empty. Only the configured database is
+// connected, so backend can override it with memory but not with another
+// database.
+func NewStorage(iConfig config.IConfig, backend string) (Storage, error) {
+	if backend == "" {
+		backend = iConfig.GetStorageBackend()
+	}
+
+	switch backend {
+	case config.STORAGE_POSTGRES:
+		if iConfig.GetPostgres() == nil {
+			return Storage{}, fmt.Errorf("storage backend %s is not configured", backend)
+		}
+		return Storage{
+			Answersheet: postgres.NewAnswersheetRepository(iConfig),
+			Test:        postgres.NewTestRepository(iConfig),
+			Outbox:      postgres.NewOutboxRepository(iConfig),
+
+			Accommodation: postgres.NewAccommodationRepository(iConfig),
+		}, nil
+	case config.STORAGE_MEMORY:
+		store := memory.NewStore()
+		return Storage{
+			Answersheet: memory.NewAnswersheetRepository(store),
+			Test:        memory.NewTestRepository(store),
+			Outbox:      memory.NewOutboxRepository(store),
+
+			Accommodation: memory.NewAccommodationRepository(store),
+		}, nil
+	}
+
+	if iConfig.GetMongo() == nil {
+		return Storage{}, fmt.Errorf("storage backend %s is not configured", backend)
+	}
+	return Storage{
+		Answersheet: repository.NewAnswersheetRepository(iConfig),
+		Test:        repository.NewTestRepository(iConfig),
+		Outbox:      repository.NewOutboxRepository(iConfig),
+
+		Accommodation: repository.NewAccommodationRepository(iConfig),
+	}, nil
+}